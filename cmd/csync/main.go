@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
 	"github.com/ibexmonj/ContribSync/pkg/plugins"
 	"github.com/spf13/cobra"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/ibexmonj/ContribSync/commands"
 )
@@ -31,8 +34,17 @@ func main() {
 	pluginManager.LoadCorePlugins()
 
 	rootCmd.AddCommand(commands.NewPluginCommand(pluginManager))
+	rootCmd.AddCommand(commands.NewSyncCommand(pluginManager))
+	rootCmd.AddCommand(commands.NewStoreCommand())
+	rootCmd.AddCommand(commands.NewReportCommand(pluginManager))
+	rootCmd.AddCommand(commands.NewSummarizeCommand(pluginManager))
+	rootCmd.AddCommand(commands.NewPromptCommand())
 
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl+C cancels the context so long-running fetches can stop cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		logger.Logger.Error().Err(err).Msg("Failed to execute command")
 		os.Exit(1)
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/ibexmonj/ContribSync/pkg/logger"
 	"github.com/spf13/cobra"
 	"regexp"
+	"strings"
 )
 
 func ShowConfig(cfg *config.Config) {
@@ -16,9 +17,32 @@ func ShowConfig(cfg *config.Config) {
 	fmt.Printf("   📝 Title: %s\n", cfg.Reminder.Title)
 	fmt.Printf("   💬 Message: %s\n", cfg.Reminder.Message)
 
+	fmt.Printf("\n👤 Identity:\n")
+	fmt.Printf("   🙋 Name: %s, Role: %s\n", cfg.Identity.Name, cfg.Identity.Role)
+	fmt.Printf("   📧 Emails: %s\n", strings.Join(cfg.Identity.Emails, ", "))
+	fmt.Printf("   🐙 GitHub Login: %s\n", cfg.Identity.GitHubLogin)
+	fmt.Printf("   🎫 Jira Account ID: %s\n", cfg.Identity.JiraAccountID)
+
 	fmt.Printf("\n🔧 Plugin Settings:\n")
-	fmt.Printf("   🏷️ Jira: Enabled: %t, Base URL: %s\n", cfg.Plugins.Jira.Enabled, cfg.Plugins.Jira.BaseURL)
-	fmt.Printf("   🏷️ GitHub: Enabled: %t, API Token: %s\n", cfg.Plugins.GitHub.Enabled, maskToken(cfg.Plugins.GitHub.APIToken))
+	fmt.Printf("   🏷️ Jira: Enabled: %t, Base URL: %s, Auth: %s, API Version: %d, Projects: %s, Story Points Field: %s\n", cfg.Plugins.Jira.Enabled, cfg.Plugins.Jira.BaseURL, cfg.Plugins.Jira.Auth, cfg.Plugins.Jira.APIVersion, strings.Join(cfg.Plugins.Jira.Projects, ", "), cfg.Plugins.Jira.StoryPointsField)
+	for name, jql := range cfg.Plugins.Jira.Queries {
+		fmt.Printf("      🔎 Query %s: %s\n", name, jql)
+	}
+	fmt.Printf("   🏷️ GitHub: Enabled: %t, API Token: %s, Repos: %s, Max PRs: %d, Concurrency: %d, Backend: %s\n", cfg.Plugins.GitHub.Enabled, maskToken(cfg.Plugins.GitHub.APIToken), strings.Join(cfg.Plugins.GitHub.Repos, ", "), cfg.Plugins.GitHub.MaxPRs, cfg.Plugins.GitHub.Concurrency, cfg.Plugins.GitHub.Backend)
+	if cfg.Plugins.GitHub.BaseURL != "" {
+		fmt.Printf("      🏢 Base URL: %s, Upload URL: %s\n", cfg.Plugins.GitHub.BaseURL, cfg.Plugins.GitHub.UploadURL)
+	}
+	for _, host := range cfg.Plugins.GitHub.Hosts {
+		fmt.Printf("      🏢 Host %s: Base URL: %s, Token Env: %s, Repos: %s\n", host.Name, host.BaseURL, host.TokenEnv, strings.Join(host.Repos, ", "))
+	}
+
+	fmt.Printf("\n📦 Store Settings:\n")
+	fmt.Printf("   📁 Dir: %s\n", cfg.Store.Dir)
+
+	fmt.Printf("\n🧠 LLM Settings:\n")
+	fmt.Printf("   🤖 Provider: %s, Model: %s, Base URL: %s, Temperature: %g, Max Tokens: %d\n", cfg.LLM.Provider, cfg.LLM.Model, cfg.LLM.BaseURL, cfg.LLM.Temperature, cfg.LLM.MaxTokens)
+	fmt.Printf("   🧾 Prompts Dir: %s\n", cfg.Prompts.Dir)
+	fmt.Printf("   🧩 Summary: Group By: %s, Context Tokens: %d, Group Tokens: %d, Max Requests: %d\n", cfg.Summary.GroupBy, cfg.Summary.ContextTokens, cfg.Summary.GroupTokens, cfg.Summary.MaxRequests)
 }
 
 func SetConfig(cfg *config.Config, key, value string) error {
@@ -45,8 +69,20 @@ func SetConfig(cfg *config.Config, key, value string) error {
 		cfg.Plugins.Jira.Enabled = (value == "true")
 	case "plugins.jira.base_url":
 		cfg.Plugins.Jira.BaseURL = value
+	case "plugins.jira.auth":
+		if value != "basic" && value != "pat" && value != "oauth" {
+			return fmt.Errorf("plugins.jira.auth must be basic, pat or oauth")
+		}
+		cfg.Plugins.Jira.Auth = value
 	case "plugins.github.api_token":
 		cfg.Plugins.GitHub.APIToken = value
+	case "llm.provider":
+		if value != "openai" && value != "azure" && value != "anthropic" && value != "openai-compatible" {
+			return fmt.Errorf("llm.provider must be openai, azure, anthropic or openai-compatible")
+		}
+		cfg.LLM.Provider = value
+	case "llm.model":
+		cfg.LLM.Model = value
 	default:
 		logger.Logger.Warn().Str("key", key).Msg("Unknown configuration key")
 		return fmt.Errorf("unknown configuration key: %s", key)
@@ -2,9 +2,11 @@ package commands
 
 import (
 	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
 	"github.com/ibexmonj/ContribSync/pkg/plugins"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 func NewPluginCommand(pm *plugins.PluginManager) *cobra.Command {
@@ -33,17 +35,36 @@ func NewPluginCommand(pm *plugins.PluginManager) *cobra.Command {
 		},
 	})
 
-	pluginCmd.AddCommand(&cobra.Command{
+	var timeout time.Duration
+	execCmd := &cobra.Command{
 		Use:   "exec [name] [args...]",
 		Short: "Execute a plugin by name",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			// Plugins can run on environment variables alone, so a missing config.yaml is fine
+			if err := config.LoadConfigIfPresent(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+
+			ctx, cancel := commandContext(cmd, timeout)
+			defer cancel()
+
 			name := args[0]
-			if err := pm.ExecutePlugin(name, args[1:]); err != nil {
+			if err := pm.ExecutePlugin(ctx, name, args[1:]); err != nil {
+				if plugins.IsCancellation(err) {
+					fmt.Printf("⏹️ Plugin execution cancelled: %v\n", err)
+					return
+				}
 				fmt.Printf("Failed to execute plugin: %v\n", err)
 			}
 		},
-	})
+	}
+	execCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort the plugin after this long (e.g. 30s, 5m)")
+	// Everything after the plugin name belongs to the plugin, including its flags
+	execCmd.Flags().SetInterspersed(false)
+	pluginCmd.AddCommand(execCmd)
 
 	return pluginCmd
 }
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/prompt"
+	"github.com/spf13/cobra"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// newPromptSkeleton is the starting point for `prompt edit` on a name with no built-in
+const newPromptSkeleton = `{{/* Describe what this prompt is for */}}
+{{- define "system"}}You are an assistant summarizing work contributions.{{end}}
+Summarize my contributions from {{.Range}}:
+{{range .Contributions}}- [{{.Source}} {{.Kind}}] {{.ID}}: {{.Title}} ({{.Status}}, {{date .UpdatedAt}})
+{{end}}
+`
+
+func loadPrompts() (*prompt.Set, error) {
+	if err := config.LoadConfig(); err != nil {
+		return nil, fmt.Errorf("error loading config: %w", err)
+	}
+	return prompt.LoadFromConfig()
+}
+
+// editPrompt opens the override file for name in $VISUAL or $EDITOR, creating
+// it from the built-in (or a skeleton) first, and validates it afterwards
+func editPrompt(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid prompt name %q", name)
+	}
+
+	dir := prompt.Dir(&config.ConfigData)
+	path := filepath.Join(dir, name+prompt.Extension)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		source, ok := prompt.Builtin(name)
+		if !ok {
+			source = newPromptSkeleton
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create prompts dir: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		fmt.Printf("📝 Created %s\n", path)
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	fields := strings.Fields(editor)
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+
+	if err := prompt.Validate(path); err != nil {
+		return fmt.Errorf("%w\nThe file was kept; run `csync prompt edit %s` again to fix it", err, name)
+	}
+	fmt.Printf("✅ %s is valid.\n", path)
+	return nil
+}
+
+func NewPromptCommand() *cobra.Command {
+	promptCmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Manage AI prompt templates",
+		Long: `List, view and customise the text/template prompts used for AI summaries.
+Templates in the prompts directory (prompts.dir, default "prompts" next to config.yaml) override the built-ins.`,
+	}
+
+	promptCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List prompt templates",
+		Run: func(cmd *cobra.Command, args []string) {
+			set, err := loadPrompts()
+			if err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load prompt templates")
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			fmt.Printf("\n🧾 Prompt Templates (overrides in %s):\n", prompt.Dir(&config.ConfigData))
+			for _, t := range set.List() {
+				origin := "built-in"
+				if !t.Builtin() {
+					origin = t.Path
+				}
+				fmt.Printf("   - %s: %s (%s)\n", t.Name, t.Description, origin)
+			}
+		},
+	})
+
+	promptCmd.AddCommand(&cobra.Command{
+		Use:   "show [name]",
+		Short: "Print a prompt template",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			set, err := loadPrompts()
+			if err == nil {
+				var t *prompt.Template
+				if t, err = set.Get(args[0]); err == nil {
+					fmt.Print(t.Source)
+					return
+				}
+			}
+			logger.Logger.Error().Err(err).Str("prompt", args[0]).Msg("Failed to show prompt template")
+			fmt.Printf("❌ Error: %v\n", err)
+		},
+	})
+
+	promptCmd.AddCommand(&cobra.Command{
+		Use:   "edit [name]",
+		Short: "Edit a prompt template in $EDITOR, overriding the built-in",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.LoadConfig(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+			if err := editPrompt(args[0]); err != nil {
+				logger.Logger.Error().Err(err).Str("prompt", args[0]).Msg("Failed to edit prompt template")
+				fmt.Printf("❌ Error: %v\n", err)
+			}
+		},
+	})
+
+	return promptCmd
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"github.com/gen2brain/beeep"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
@@ -31,7 +32,7 @@ func SendDesktopNotification(title, message string) error {
 	return err
 }
 
-func StartReminder() {
+func StartReminder(ctx context.Context) {
 	logger.Logger.Info().Msg("⏰ Reminder service started. Press Ctrl+C to stop.")
 
 	for {
@@ -52,21 +53,35 @@ func StartReminder() {
 				fmt.Printf("❌ Failed to send notification: %v\n", err)
 			}
 
-			time.Sleep(60 * time.Second) // Wait to avoid sending notifications every second
-		} else {
-			time.Sleep(10 * time.Second) // Check every 10 seconds
+			if !sleepContext(ctx, 60*time.Second) { // Wait to avoid sending notifications every second
+				break
+			}
+		} else if !sleepContext(ctx, 10*time.Second) { // Check every 10 seconds
+			break
 		}
 	}
+
+	logger.Logger.Info().Msg("⏹️ Reminder service stopped")
+}
+
+// sleepContext waits for d, returning false early if ctx is cancelled
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
 }
 
-func ReminderCommand(args []string) {
+func ReminderCommand(ctx context.Context, args []string) {
 	if len(args) > 0 && args[0] == "test" {
 		TestReminder()
 		return
 	}
 
 	fmt.Println("🔔 Starting the reminder service...")
-	StartReminder()
+	StartReminder(ctx)
 }
 
 func SendMacNotification(title, message string) error {
@@ -102,7 +117,9 @@ func NewReminderCommand() *cobra.Command {
 		Use:   "start",
 		Short: "Start the reminder service",
 		Run: func(cmd *cobra.Command, args []string) {
-			StartReminder()
+			ctx, cancel := commandContext(cmd, 0)
+			defer cancel()
+			StartReminder(ctx)
 		},
 	})
 
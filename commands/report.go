@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/plugins"
+	"github.com/ibexmonj/ContribSync/pkg/report"
+	"github.com/spf13/cobra"
+	"strings"
+	"time"
+)
+
+func ShowReport(rep report.Report) {
+	fmt.Printf("\n📊 Contribution Report: %s\n", rep.Range)
+
+	if rep.Total == 0 {
+		fmt.Println("\n❌ No contributions found.")
+		return
+	}
+
+	for _, sg := range rep.Sources {
+		fmt.Printf("\n🔌 %s (%d)\n", sg.Source, sg.Count)
+		for _, pg := range sg.Projects {
+			fmt.Printf("   📁 %s (%d)\n", pg.Project, pg.Count)
+			for _, status := range pg.Statuses {
+				fmt.Printf("      🏷️ %s (%d)\n", status.Status, len(status.Items))
+				for _, item := range status.Items {
+					fmt.Printf("         - %s\n", formatReportItem(item))
+				}
+			}
+		}
+	}
+
+	fmt.Printf("\n🔢 Total: %d contributions\n", rep.Total)
+}
+
+func formatReportItem(item report.Item) string {
+	id := item.ID
+	switch {
+	case item.Source == contrib.SourceGitHub && (item.Kind == contrib.KindPullRequest || item.Kind == contrib.KindIssue):
+		id = "#" + id
+	case item.Kind != contrib.KindIssue && len(item.Links) > 0:
+		id = fmt.Sprintf("%s on #%s", item.Kind, item.Links[0].Target) // Reviews and comments name their PR/issue
+	}
+
+	line := fmt.Sprintf("[%s] %s", id, item.Title)
+	if item.Commits > 0 {
+		line += fmt.Sprintf(" (%d commits)", item.Commits)
+	}
+	if credit := item.MetadataStrings("credit"); len(credit) > 0 {
+		line += " (" + strings.Join(credit, ", ") + ")"
+	}
+	if !item.UpdatedAt.IsZero() {
+		line += " · " + item.UpdatedAt.Local().Format(time.DateOnly)
+	}
+	if item.URL != "" {
+		line += " · " + item.URL
+	}
+	return line
+}
+
+func NewReportCommand(pm *plugins.PluginManager) *cobra.Command {
+	var since, until, jiraQuery string
+	var me, offline bool
+	var timeout time.Duration
+
+	reportCmd := &cobra.Command{
+		Use:   "report",
+		Short: "Show a consolidated contribution report across all enabled plugins",
+		Long: `Gather GitHub PRs/commits and Jira issues for a date range and group them by source, repo/project and status.
+Example:
+  csync report --since 2026-07-01 --until 2026-09-30 --me
+  csync report --since last-quarter --jira-query team-bugs`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.LoadConfig(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+
+			r, err := daterange.Parse(since, until)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			ctx, cancel := commandContext(cmd, timeout)
+			defer cancel()
+
+			named := make(map[string]string)
+			if jiraQuery != "" {
+				named["jira"] = jiraQuery
+			}
+
+			contributions, err := gatherContributions(ctx, pm, &config.ConfigData, r, me, offline, named)
+			if err != nil && !plugins.IsCancellation(err) {
+				logger.Logger.Error().Err(err).Msg("Failed to gather contributions")
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			ShowReport(report.Build(r, contributions))
+			if err != nil {
+				fmt.Printf("\n⚠️ Stopped early (%v); the report is incomplete.\n", err)
+			}
+		},
+	}
+
+	reportCmd.Flags().StringVar(&since, "since", "", "Start of the report period (YYYY-MM-DD, 30d, last-quarter, ...)")
+	reportCmd.Flags().StringVar(&until, "until", "", "End of the report period, inclusive (YYYY-MM-DD, 7d, today, ...)")
+	reportCmd.Flags().BoolVar(&me, "me", false, "Only include contributions by the configured identity")
+	reportCmd.Flags().BoolVar(&offline, "offline", false, "Report from the local store instead of querying plugins")
+	reportCmd.Flags().StringVar(&jiraQuery, "jira-query", "", "Select Jira issues with this query from plugins.jira.queries")
+	reportCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort fetching after this long (e.g. 30s, 5m)")
+	return reportCmd
+}
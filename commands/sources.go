@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/plugins"
+	"github.com/ibexmonj/ContribSync/pkg/store"
+)
+
+// enabledSources returns the loaded source plugins switched on in the config
+func enabledSources(pm *plugins.PluginManager, cfg *config.Config) []string {
+	enabled := map[string]bool{
+		"github": cfg.Plugins.GitHub.Enabled,
+		"jira":   cfg.Plugins.Jira.Enabled,
+	}
+
+	var names []string
+	for _, name := range pm.Sources() {
+		if enabled[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// queryFor builds the query for a source from the configured identity and targets
+func queryFor(cfg *config.Config, source string) plugins.Query {
+	var q plugins.Query
+
+	switch source {
+	case "github":
+		for _, email := range cfg.Identity.Emails {
+			q.Identities = append(q.Identities, contrib.Identity{Email: email})
+		}
+		if cfg.Identity.GitHubLogin != "" {
+			q.Identities = append(q.Identities, contrib.Identity{Login: cfg.Identity.GitHubLogin})
+		}
+		q.Targets = append(q.Targets, cfg.Plugins.GitHub.Repos...)
+		for _, host := range cfg.Plugins.GitHub.Hosts {
+			for _, repo := range host.Repos {
+				q.Targets = append(q.Targets, host.Name+":"+repo)
+			}
+		}
+	case "jira":
+		if cfg.Identity.JiraAccountID != "" {
+			q.Identities = append(q.Identities, contrib.Identity{Login: cfg.Identity.JiraAccountID})
+		} else {
+			for _, email := range cfg.Identity.Emails {
+				q.Identities = append(q.Identities, contrib.Identity{Email: email})
+			}
+		}
+		q.Targets = cfg.Plugins.Jira.Projects
+	}
+
+	return q
+}
+
+// openStore opens the contribution store configured in cfg
+func openStore(cfg *config.Config) (*store.Store, error) {
+	dir := cfg.Store.Dir
+	if dir == "" {
+		var err error
+		if dir, err = store.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return store.Open(dir)
+}
+
+// gatherContributions collects contributions in r from every enabled source,
+// live or, when offline is set, from the local store. With me set only the
+// configured identity's work is kept. named maps a source to one of its saved
+// queries to run in place of the default selection. On cancellation the
+// contributions gathered so far are returned along with the error.
+func gatherContributions(ctx context.Context, pm *plugins.PluginManager, cfg *config.Config, r daterange.Range, me, offline bool, named map[string]string) ([]contrib.Contribution, error) {
+	if offline {
+		if len(named) > 0 {
+			return nil, fmt.Errorf("saved queries can't be run with --offline")
+		}
+		return storedContributions(cfg, r, me)
+	}
+
+	sources := enabledSources(pm, cfg)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no plugins enabled; enable one with plugins.<name>.enabled in config.yaml")
+	}
+
+	var all []contrib.Contribution
+	for _, name := range sources {
+		q := queryFor(cfg, name)
+		if !me {
+			q.Identities = nil
+		}
+		q.Since, q.Until = r.Since, r.Until
+		q.Named = named[name]
+
+		contributions, err := pm.Fetch(ctx, name, q)
+		all = append(all, contributions...)
+		if plugins.IsCancellation(err) {
+			return all, err
+		}
+		// The plugin has already warned about its cap, and what it fetched is still good
+		if err != nil && !plugins.IsTruncated(err) {
+			logger.Logger.Error().Err(err).Str("plugin", name).Msg("Failed to fetch contributions")
+			fmt.Printf("⚠️ Skipping %s: %v\n", name, err)
+		}
+	}
+	return all, nil
+}
+
+func storedContributions(cfg *config.Config, r daterange.Range, me bool) ([]contrib.Contribution, error) {
+	s, err := openStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	window := plugins.Query{Since: r.Since, Until: r.Until}
+	var inRange []contrib.Contribution
+	for _, c := range s.All() {
+		if window.InRange(c.UpdatedAt) {
+			inRange = append(inRange, c)
+		}
+	}
+	if !me {
+		return inRange, nil
+	}
+
+	mine := func(c contrib.Contribution) bool {
+		return c.AuthoredBy(queryFor(cfg, string(c.Source)).Identities)
+	}
+
+	// Like a live fetch, a PR counts as mine when it contains my commits
+	prsWithMyCommits := make(map[string]bool)
+	for _, c := range inRange {
+		if c.Kind != contrib.KindCommit || !mine(c) {
+			continue
+		}
+		for _, link := range c.Links {
+			if link.Rel == "pull_request" {
+				prsWithMyCommits[c.Project+"#"+link.Target] = true
+			}
+		}
+	}
+
+	var matched []contrib.Contribution
+	for _, c := range inRange {
+		if mine(c) || (c.Kind == contrib.KindPullRequest && prsWithMyCommits[c.Project+"#"+c.ID]) {
+			matched = append(matched, c)
+		}
+	}
+	return matched, nil
+}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/store"
+	"github.com/spf13/cobra"
+	"sort"
+	"time"
+)
+
+func ShowStoreStats(stats store.Stats) {
+	fmt.Printf("\n📦 Contribution Store:\n")
+	fmt.Printf("   📁 Path: %s\n", stats.Path)
+	fmt.Printf("   🧬 Schema Version: %d\n", stats.SchemaVersion)
+	fmt.Printf("   💾 Size: %d bytes\n", stats.SizeBytes)
+	fmt.Printf("   🔄 Last Full Sync: %s\n", formatStatsTime(stats.SyncedAt))
+	fmt.Printf("   🔢 Contributions: %d\n", stats.Total)
+
+	if stats.Total == 0 {
+		return
+	}
+
+	fmt.Printf("   📅 Range: %s → %s\n", formatStatsTime(stats.Oldest), formatStatsTime(stats.Newest))
+
+	fmt.Printf("\n🔌 By Source:\n")
+	for _, name := range sortedKeys(stats.BySource) {
+		fmt.Printf("   - %s: %d\n", name, stats.BySource[name])
+	}
+
+	fmt.Printf("\n🏷️ By Kind:\n")
+	for _, name := range sortedKeys(stats.ByKind) {
+		fmt.Printf("   - %s: %d\n", name, stats.ByKind[name])
+	}
+
+	if len(stats.Cursors) > 0 {
+		fmt.Printf("\n📍 Sync Cursors:\n")
+		for _, key := range sortedKeys(stats.Cursors) {
+			fmt.Printf("   - %s: %s\n", key, formatStatsTime(stats.Cursors[key]))
+		}
+	}
+}
+
+func formatStatsTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func NewStoreCommand() *cobra.Command {
+	storeCmd := &cobra.Command{
+		Use:   "store",
+		Short: "Inspect the local contribution store",
+	}
+
+	storeCmd.AddCommand(&cobra.Command{
+		Use:   "stats",
+		Short: "Show what the local store contains",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.LoadConfig(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+
+			s, err := openStore(&config.ConfigData)
+			if err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to open store")
+				fmt.Printf("❌ Error opening store: %v\n", err)
+				return
+			}
+			ShowStoreStats(s.Stats())
+		},
+	})
+
+	return storeCmd
+}
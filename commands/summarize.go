@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/llm"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/plugins"
+	"github.com/ibexmonj/ContribSync/pkg/prompt"
+	"github.com/ibexmonj/ContribSync/pkg/summary"
+	"github.com/spf13/cobra"
+	"time"
+)
+
+func NewSummarizeCommand(pm *plugins.PluginManager) *cobra.Command {
+	var since, until, promptName, groupBy string
+	var me, offline, dryRun bool
+	var maxTokens int
+	var timeout time.Duration
+
+	summarizeCmd := &cobra.Command{
+		Use:   "summarize",
+		Short: "Write an AI self-evaluation from your GitHub and Jira contributions",
+		Long: `Gather GitHub PRs, reviews and commits and Jira issues for a date range and summarize them in one self-evaluation.
+PRs whose title or branch names a Jira issue are described together with it, as one feature.
+Example:
+  csync summarize --since 2026-07-01 --until 2026-09-30
+  csync summarize --since last-quarter --offline --prompt weekly-update`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.LoadConfig(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+			cfg := &config.ConfigData
+
+			r, err := daterange.Parse(since, until)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			prompts, err := prompt.LoadFromConfig()
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			opts := summary.OptionsFromConfig(cfg)
+			opts.Template = promptName
+			if cmd.Flags().Changed("group-by") {
+				opts.GroupBy = groupBy
+			}
+			if cmd.Flags().Changed("max-tokens") {
+				opts.MaxTokens = maxTokens
+			}
+			tmpl, err := prompts.Get(opts.Template)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			ctx, cancel := commandContext(cmd, timeout)
+			defer cancel()
+
+			contributions, err := gatherContributions(ctx, pm, cfg, r, me, offline, nil)
+			if err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to gather contributions")
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			features := summary.LinkFeatures(contributions, cfg.Plugins.Jira.Projects)
+			if len(features) == 0 {
+				fmt.Printf("\n❌ No contributions found for %s.\n", r)
+				return
+			}
+
+			user := prompt.ProfileFromConfig(cfg)
+			if dryRun {
+				rendered, err := tmpl.Render(prompt.Data{Contributions: features, Range: r, User: user, Words: summary.Words(opts.MaxTokens)})
+				if err != nil {
+					fmt.Printf("❌ Error: %v\n", err)
+					return
+				}
+				fmt.Printf("🧠 System:\n%s\n\n💬 Prompt:\n%s\n", rendered.System, rendered.User)
+				tokens := summary.EstimateTokens(rendered.System) + summary.EstimateTokens(rendered.User)
+				fmt.Printf("\nℹ️ %d items from %d contributions, about %d tokens", len(features), len(contributions), tokens)
+				if tokens > opts.ContextTokens {
+					fmt.Printf("; over summary.context_tokens (%d), so it would be summarized in groups", opts.ContextTokens)
+				}
+				fmt.Println(".")
+				return
+			}
+
+			provider, err := llm.FromConfig()
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+			result, err := summary.New(provider, prompts, opts).Summarize(ctx, features, r, user)
+			if err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to summarize contributions")
+				fmt.Printf("❌ Error: %v\n", err)
+				return
+			}
+
+			fmt.Printf("\n📝 AI Self-Evaluation: %s\n\n%s\n", r, result.Text)
+			fmt.Printf("\nℹ️ %s\n", result.Coverage())
+			if result.Truncated {
+				fmt.Println("⚠️ The summary hit the token limit and may be cut off; raise --max-tokens or llm.max_tokens.")
+			}
+		},
+	}
+
+	summarizeCmd.Flags().StringVar(&since, "since", "", "Start of the period (YYYY-MM-DD, 30d, last-quarter, ...)")
+	summarizeCmd.Flags().StringVar(&until, "until", "", "End of the period, inclusive (YYYY-MM-DD, 7d, today, ...)")
+	summarizeCmd.Flags().BoolVar(&me, "me", true, "Only include contributions by the configured identity")
+	summarizeCmd.Flags().BoolVar(&offline, "offline", false, "Summarize from the local store instead of querying plugins")
+	summarizeCmd.Flags().StringVar(&promptName, "prompt", prompt.SelfEvaluation, "Prompt template to use (see csync prompt list)")
+	summarizeCmd.Flags().StringVar(&groupBy, "group-by", "", "Group large sets by project, epic or month before summarizing (default summary.group_by)")
+	summarizeCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Length of the summary (default llm.max_tokens)")
+	summarizeCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the prompt instead of sending it")
+	summarizeCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort after this long (e.g. 30s, 5m)")
+	return summarizeCmd
+}
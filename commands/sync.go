@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/plugins"
+	"github.com/ibexmonj/ContribSync/pkg/store"
+	"github.com/spf13/cobra"
+	"strings"
+	"time"
+)
+
+func NewSyncCommand(pm *plugins.PluginManager) *cobra.Command {
+	var timeout time.Duration
+	var full bool
+
+	syncCmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Fetch contributions from every enabled plugin into the local store",
+		Long: `Fetch contributions from every enabled plugin into the local store.
+Only items changed since the previous sync are fetched; use --full to backfill everything.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := config.LoadConfig(); err != nil {
+				logger.Logger.Error().Err(err).Msg("Failed to load configuration")
+				fmt.Printf("❌ Error loading config: %v\n", err)
+				return
+			}
+
+			ctx, cancel := commandContext(cmd, timeout)
+			defer cancel()
+
+			if err := SyncContributions(ctx, pm, &config.ConfigData, full); err != nil {
+				logger.Logger.Error().Err(err).Msg("Sync failed")
+				fmt.Printf("❌ Sync failed: %v\n", err)
+			}
+		},
+	}
+
+	syncCmd.Flags().DurationVar(&timeout, "timeout", 0, "Abort the sync after this long (e.g. 30s, 5m)")
+	syncCmd.Flags().BoolVar(&full, "full", false, "Ignore sync cursors and fetch complete history")
+	return syncCmd
+}
+
+// SyncContributions fetches from every enabled source and upserts the results
+// into the store. Each source target resumes from its cursor unless full is set.
+// Partial results from a cancelled or capped fetch are still saved, but only a
+// complete fetch advances its cursor.
+func SyncContributions(ctx context.Context, pm *plugins.PluginManager, cfg *config.Config, full bool) error {
+	sources := enabledSources(pm, cfg)
+	if len(sources) == 0 {
+		fmt.Println("⚠️ No plugins enabled. Enable one with plugins.<name>.enabled in config.yaml.")
+		return nil
+	}
+
+	s, err := openStore(cfg)
+	if err != nil {
+		return err
+	}
+
+	var failed, incomplete int
+	for _, name := range sources {
+		for _, q := range cursorQueries(name, queryFor(cfg, name)) {
+			if ctx.Err() != nil {
+				break
+			}
+
+			label := cursorKey(name, q)
+			cursor := s.Cursor(label)
+			if !full {
+				q.Since = cursor
+			}
+
+			contributions, err := pm.Fetch(ctx, name, q)
+			added, updated := s.Upsert(contributions...)
+			advanceCursor(s, label, contributions, err)
+
+			switch {
+			case err == nil:
+				fmt.Printf("✅ %s: %d new, %d updated%s\n", label, added, updated, sinceNote(q.Since))
+			case plugins.IsTruncated(err):
+				fmt.Printf("⚠️ %s: %d new, %d updated%s; results were capped, so the cursor was not advanced\n",
+					label, added, updated, sinceNote(q.Since))
+				incomplete++
+			case plugins.IsCancellation(err):
+				fmt.Printf("⏹️ %s: stopped early (%v), saved %d new, %d updated\n", label, err, added, updated)
+				failed++
+			default:
+				logger.Logger.Error().Err(err).Str("plugin", name).Msg("Failed to sync plugin")
+				fmt.Printf("❌ %s: %v\n", label, err)
+				failed++
+			}
+		}
+	}
+
+	if failed == 0 && incomplete == 0 {
+		s.MarkSynced(time.Now())
+	}
+	if err := s.Save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("📦 Store: %s\n", s.Path())
+	if failed > 0 {
+		return fmt.Errorf("%d plugin(s) did not sync completely", failed)
+	}
+	return nil
+}
+
+// cursorQueries splits a source query into the units a cursor is kept for.
+// GitHub repos advance independently; a Jira JQL is a single unit.
+func cursorQueries(source string, q plugins.Query) []plugins.Query {
+	if source != "github" || len(q.Targets) <= 1 {
+		return []plugins.Query{q}
+	}
+
+	queries := make([]plugins.Query, len(q.Targets))
+	for i, target := range q.Targets {
+		queries[i] = q
+		queries[i].Targets = []string{target}
+	}
+	return queries
+}
+
+// cursorKey names the cursor for a source query, e.g. "github:owner/repo"
+func cursorKey(source string, q plugins.Query) string {
+	if len(q.Targets) == 0 {
+		return source
+	}
+	return source + ":" + strings.Join(q.Targets, ",")
+}
+
+// advanceCursor moves the cursor for key up to the newest of contributions when
+// the fetch that returned them read everything since the cursor. A fetch cut
+// short by a cap, an error or cancellation leaves the cursor where it was, as
+// moving it would make the next sync skip the items that were never read.
+func advanceCursor(s *store.Store, key string, contributions []contrib.Contribution, err error) bool {
+	if err != nil {
+		return false
+	}
+	s.SetCursor(key, newestUpdate(contributions))
+	return true
+}
+
+// newestUpdate returns the latest UpdatedAt among contributions
+func newestUpdate(contributions []contrib.Contribution) time.Time {
+	var newest time.Time
+	for _, c := range contributions {
+		if c.UpdatedAt.After(newest) {
+			newest = c.UpdatedAt
+		}
+	}
+	return newest
+}
+
+func sinceNote(since time.Time) string {
+	if since.IsZero() {
+		return " (full)"
+	}
+	return fmt.Sprintf(" (since %s)", since.Local().Format("2006-01-02 15:04"))
+}
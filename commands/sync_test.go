@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/plugins"
+	"github.com/ibexmonj/ContribSync/pkg/report"
+	"github.com/ibexmonj/ContribSync/pkg/store"
+)
+
+func TestAdvanceCursor(t *testing.T) {
+	previous := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	newest := previous.Add(48 * time.Hour)
+	fetched := []contrib.Contribution{
+		{Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "CS-1", UpdatedAt: previous.Add(time.Hour)},
+		{Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "CS-2", UpdatedAt: newest},
+	}
+
+	tests := []struct {
+		name          string
+		contributions []contrib.Contribution
+		err           error
+		want          time.Time
+	}{
+		{name: "complete", contributions: fetched, want: newest},
+		{name: "nothing new", want: previous},
+		{name: "capped", contributions: fetched, err: fmt.Errorf("failed to fetch Jira issues: %w", plugins.ErrTruncated), want: previous},
+		{name: "cancelled", contributions: fetched, err: context.DeadlineExceeded, want: previous},
+		{name: "failed", contributions: fetched, err: errors.New("401 Unauthorized"), want: previous},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := store.Open(t.TempDir())
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.SetCursor("jira", previous)
+
+			advanced := advanceCursor(s, "jira", tc.contributions, tc.err)
+			if advanced != (tc.err == nil) {
+				t.Errorf("advanceCursor = %v with err %v", advanced, tc.err)
+			}
+			if got := s.Cursor("jira"); !got.Equal(tc.want) {
+				t.Errorf("Cursor = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCursorQueries(t *testing.T) {
+	q := plugins.Query{Targets: []string{"acme/api", "ghes:platform/deploy"}}
+
+	queries := cursorQueries("github", q)
+	if len(queries) != 2 {
+		t.Fatalf("got %d queries, want one per repo", len(queries))
+	}
+	for i, want := range []string{"github:acme/api", "github:ghes:platform/deploy"} {
+		if got := cursorKey("github", queries[i]); got != want {
+			t.Errorf("cursorKey = %q, want %q", got, want)
+		}
+	}
+
+	jira := cursorQueries("jira", plugins.Query{Targets: []string{"CS", "OPS"}})
+	if len(jira) != 1 || cursorKey("jira", jira[0]) != "jira:CS,OPS" {
+		t.Errorf("jira queries = %+v, want a single CS,OPS unit", jira)
+	}
+}
+
+// creditSource is a Jira source that answers each fetch with the next of its credit reasons for CS-1
+type creditSource struct {
+	credit [][]string
+	since  []time.Time
+}
+
+func (s *creditSource) Init() error                                      { return nil }
+func (s *creditSource) Execute(ctx context.Context, args []string) error { return nil }
+func (s *creditSource) Info() (string, string)                           { return "jira", "fake Jira" }
+
+func (s *creditSource) Fetch(ctx context.Context, q plugins.Query) ([]contrib.Contribution, error) {
+	s.since = append(s.since, q.Since)
+	credit := s.credit[len(s.since)-1]
+	updated := time.Date(2026, 9, len(s.since), 0, 0, 0, 0, time.UTC)
+	return []contrib.Contribution{{
+		Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "CS-1", Project: "CS", Status: "Done",
+		UpdatedAt: updated, ClosedAt: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		Metadata: map[string]any{"credit": credit},
+	}}, nil
+}
+
+func TestSyncKeepsCredit(t *testing.T) {
+	source := &creditSource{credit: [][]string{{"moved to Done"}, {"commented (1)"}}}
+	pm := plugins.NewPluginManager()
+	pm.RegisterPlugin(source)
+	var cfg config.Config
+	cfg.Plugins.Jira.Enabled = true
+	cfg.Store.Dir = t.TempDir()
+
+	for range 2 {
+		if err := SyncContributions(context.Background(), pm, &cfg, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(source.since) != 2 || source.since[1].IsZero() {
+		t.Fatalf("fetched since %v, want the second sync to resume from the cursor", source.since)
+	}
+
+	s, err := store.Open(cfg.Store.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := s.All()
+	if len(all) != 1 {
+		t.Fatalf("stored %d contributions, want CS-1 only", len(all))
+	}
+	if got := all[0].MetadataStrings("credit"); !slices.Equal(got, []string{"moved to Done", "commented (1)"}) {
+		t.Errorf("credit = %v, want the first sync's reasons kept", got)
+	}
+	if !report.Delivered(all[0]) {
+		t.Error("CS-1 is no longer delivered after the second sync")
+	}
+}
@@ -1,9 +1,12 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/viper"
 	"regexp"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -12,21 +15,90 @@ type Config struct {
 		Title   string `mapstructure:"title"`
 		Message string `mapstructure:"message"`
 	} `mapstructure:"reminder"`
+	Identity struct {
+		Name          string   `mapstructure:"name"` // Used in prompts
+		Role          string   `mapstructure:"role"` // e.g. "Senior Backend Engineer", used in prompts
+		Emails        []string `mapstructure:"emails"`
+		GitHubLogin   string   `mapstructure:"github_login"`
+		JiraAccountID string   `mapstructure:"jira_account_id"`
+	} `mapstructure:"identity"`
 	Plugins struct {
 		Jira struct {
-			Enabled bool   `mapstructure:"enabled"`
-			BaseURL string `mapstructure:"base_url"`
+			Enabled    bool              `mapstructure:"enabled"`
+			BaseURL    string            `mapstructure:"base_url"`
+			Projects   []string          `mapstructure:"projects"`
+			Auth       string            `mapstructure:"auth"`        // basic (Cloud), pat (Server/Data Center) or oauth
+			CloudID    string            `mapstructure:"cloud_id"`    // Cloud site ID, required for oauth
+			APIVersion int               `mapstructure:"api_version"` // 2, or 3 for Atlassian Document Format descriptions
+			CreditBy   string            `mapstructure:"credit_by"`   // activity (changelog, comments, worklogs) or assignee
+			Queries    map[string]string `mapstructure:"queries"`     // Named JQL for `jira search --query` and `report --jira-query`; names are case-insensitive
+			// Field holding story points, by name or ID; empty tries "Story Points" and "Story point estimate"
+			StoryPointsField string `mapstructure:"story_points_field"`
 		} `mapstructure:"jira"`
 		GitHub struct {
-			Enabled  bool   `mapstructure:"enabled"`
-			APIToken string `mapstructure:"api_token"`
+			Enabled     bool         `mapstructure:"enabled"`
+			APIToken    string       `mapstructure:"api_token"`
+			BaseURL     string       `mapstructure:"base_url"`   // GitHub Enterprise Server API URL; empty for github.com
+			UploadURL   string       `mapstructure:"upload_url"` // Defaults to base_url
+			Repos       []string     `mapstructure:"repos"`
+			Hosts       []GitHubHost `mapstructure:"hosts"`       // Extra GitHub instances queried alongside the one above
+			MaxPRs      int          `mapstructure:"max_prs"`     // Per repo; older PRs are left out
+			Concurrency int          `mapstructure:"concurrency"` // Parallel commit requests
+			Backend     string       `mapstructure:"backend"`     // rest or graphql
 		} `mapstructure:"github"`
 	} `mapstructure:"plugins"`
+	Store struct {
+		Dir string `mapstructure:"dir"` // Defaults to the user data dir when empty
+	} `mapstructure:"store"`
+	LLM     LLM `mapstructure:"llm"`
+	Summary struct {
+		GroupBy       string `mapstructure:"group_by"`       // project, epic or month, for sets too large for one prompt
+		ContextTokens int    `mapstructure:"context_tokens"` // Largest prompt sent to the model at once
+		GroupTokens   int    `mapstructure:"group_tokens"`   // Length of each group's summary
+		MaxRequests   int    `mapstructure:"max_requests"`   // Cap on group summaries; 0 for no cap
+	} `mapstructure:"summary"`
+	Prompts struct {
+		Dir string `mapstructure:"dir"` // Template overrides; defaults to "prompts" next to config.yaml
+	} `mapstructure:"prompts"`
+}
+
+// LLM selects the language model used for AI summaries
+type LLM struct {
+	Provider    string        `mapstructure:"provider"`    // openai, azure, anthropic or openai-compatible
+	Model       string        `mapstructure:"model"`       // Defaults per provider; required for openai-compatible
+	BaseURL     string        `mapstructure:"base_url"`    // API root, e.g. http://localhost:11434/v1 for Ollama
+	APIKeyEnv   string        `mapstructure:"api_key_env"` // Environment variable holding the key; defaults per provider
+	Deployment  string        `mapstructure:"deployment"`  // Azure deployment name; defaults to model
+	APIVersion  string        `mapstructure:"api_version"` // Azure API version
+	Temperature float64       `mapstructure:"temperature"`
+	MaxTokens   int           `mapstructure:"max_tokens"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+}
+
+// GitHubHost is an additional GitHub instance, typically GitHub Enterprise Server.
+// Its repos are referred to as "<name>:owner/repo" on the command line and in reports.
+type GitHubHost struct {
+	Name      string   `mapstructure:"name"`
+	BaseURL   string   `mapstructure:"base_url"`
+	UploadURL string   `mapstructure:"upload_url"`
+	TokenEnv  string   `mapstructure:"token_env"` // Environment variable holding the token; defaults to GITHUB_TOKEN
+	Login     string   `mapstructure:"login"`     // Overrides identity.github_login on this host
+	Repos     []string `mapstructure:"repos"`     // owner/repo or orgs fetched from this host; without any, searches cover the whole host
 }
 
 var ConfigData Config
 
 func LoadConfig() error {
+	return load(true)
+}
+
+// LoadConfigIfPresent loads config.yaml like LoadConfig, but runs on the
+// defaults and environment instead of creating the file when there is none
+func LoadConfigIfPresent() error {
+	return load(false)
+}
+
+func load(create bool) error {
 	viper.SetConfigName("config") // Name of the file (without extension)
 	viper.SetConfigType("yaml")   // File type
 	viper.AddConfigPath(".")      // Look in the current directory
@@ -35,9 +107,15 @@ func LoadConfig() error {
 	setDefaults()
 
 	if err := viper.ReadInConfig(); err != nil {
-		fmt.Println("⚠️  No config file found. Creating default config.yaml...")
-		if err := SaveConfig(); err != nil {
-			return fmt.Errorf("failed to save default config: %w", err)
+		var notFound viper.ConfigFileNotFoundError
+		if !create && !errors.As(err, &notFound) {
+			return fmt.Errorf("failed to read config: %w", err)
+		}
+		if create {
+			fmt.Println("⚠️  No config file found. Creating default config.yaml...")
+			if err := SaveConfig(); err != nil {
+				return fmt.Errorf("failed to save default config: %w", err)
+			}
 		}
 	}
 
@@ -64,6 +142,62 @@ func ValidateConfig(cfg *Config) error {
 	if !timeFormat.MatchString(cfg.Reminder.Time) {
 		return fmt.Errorf("invalid reminder time format: %s (expected HH:MM)", cfg.Reminder.Time)
 	}
+
+	if v := cfg.Plugins.Jira.APIVersion; v != 0 && v != 2 && v != 3 {
+		return fmt.Errorf("invalid plugins.jira.api_version: %d (expected 2 or 3)", v)
+	}
+
+	switch jira := cfg.Plugins.Jira; jira.Auth {
+	case "", "basic":
+	case "pat":
+		if strings.Contains(jira.BaseURL, ".atlassian.net") {
+			return fmt.Errorf("plugins.jira.auth pat is for Jira Server/Data Center; %s is Jira Cloud (use basic or oauth)", jira.BaseURL)
+		}
+	case "oauth":
+		if jira.CloudID == "" {
+			return fmt.Errorf("plugins.jira.auth oauth needs plugins.jira.cloud_id")
+		}
+	default:
+		return fmt.Errorf("invalid plugins.jira.auth: %s (expected basic, pat or oauth)", jira.Auth)
+	}
+
+	switch cfg.Plugins.Jira.CreditBy {
+	case "", "activity", "assignee":
+	default:
+		return fmt.Errorf("invalid plugins.jira.credit_by: %s (expected activity or assignee)", cfg.Plugins.Jira.CreditBy)
+	}
+
+	switch cfg.Plugins.GitHub.Backend {
+	case "", "rest", "graphql":
+	default:
+		return fmt.Errorf("invalid plugins.github.backend: %s (expected rest or graphql)", cfg.Plugins.GitHub.Backend)
+	}
+
+	switch cfg.LLM.Provider {
+	case "", "openai", "azure", "anthropic", "openai-compatible":
+	default:
+		return fmt.Errorf("invalid llm.provider: %s (expected openai, azure, anthropic or openai-compatible)", cfg.LLM.Provider)
+	}
+	switch cfg.Summary.GroupBy {
+	case "", "project", "epic", "month":
+	default:
+		return fmt.Errorf("invalid summary.group_by: %s (expected project, epic or month)", cfg.Summary.GroupBy)
+	}
+
+	if t := cfg.LLM.Temperature; t < 0 || t > 2 {
+		return fmt.Errorf("invalid llm.temperature: %g (expected 0 to 2)", t)
+	}
+
+	seen := make(map[string]bool)
+	for _, host := range cfg.Plugins.GitHub.Hosts {
+		if host.Name == "" || host.BaseURL == "" {
+			return fmt.Errorf("every plugins.github.hosts entry needs a name and base_url")
+		}
+		if seen[host.Name] {
+			return fmt.Errorf("duplicate GitHub host name: %s", host.Name)
+		}
+		seen[host.Name] = true
+	}
 	return nil
 }
 
@@ -72,9 +206,46 @@ func setDefaults() {
 	viper.SetDefault("reminder.title", "Contribution Reminder")
 	viper.SetDefault("reminder.message", "Don't forget to log your contributions!")
 
+	viper.SetDefault("identity.name", "")
+	viper.SetDefault("identity.role", "")
+	viper.SetDefault("identity.emails", []string{})
+	viper.SetDefault("identity.github_login", "")
+	viper.SetDefault("identity.jira_account_id", "")
+
 	viper.SetDefault("plugins.jira.enabled", false)
 	viper.SetDefault("plugins.jira.base_url", "")
+	viper.SetDefault("plugins.jira.projects", []string{})
+	viper.SetDefault("plugins.jira.auth", "basic")
+	viper.SetDefault("plugins.jira.cloud_id", "")
+	viper.SetDefault("plugins.jira.api_version", 2)
+	viper.SetDefault("plugins.jira.credit_by", "activity")
+	viper.SetDefault("plugins.jira.queries", map[string]string{})
+	viper.SetDefault("plugins.jira.story_points_field", "")
 
 	viper.SetDefault("plugins.github.enabled", false)
 	viper.SetDefault("plugins.github.api_token", "")
+	viper.SetDefault("plugins.github.base_url", "")
+	viper.SetDefault("plugins.github.upload_url", "")
+	viper.SetDefault("plugins.github.repos", []string{})
+	viper.SetDefault("plugins.github.hosts", []GitHubHost{})
+	viper.SetDefault("plugins.github.max_prs", 200)
+	viper.SetDefault("plugins.github.concurrency", 4)
+	viper.SetDefault("plugins.github.backend", "rest")
+
+	viper.SetDefault("store.dir", "")
+
+	viper.SetDefault("llm.provider", "openai")
+	viper.SetDefault("llm.model", "")
+	viper.SetDefault("llm.base_url", "")
+	viper.SetDefault("llm.api_key_env", "")
+	viper.SetDefault("llm.temperature", 0.3)
+	viper.SetDefault("llm.max_tokens", 500)
+	viper.SetDefault("llm.timeout", "60s")
+
+	viper.SetDefault("summary.group_by", "project")
+	viper.SetDefault("summary.context_tokens", 8000)
+	viper.SetDefault("summary.group_tokens", 300)
+	viper.SetDefault("summary.max_requests", 30)
+
+	viper.SetDefault("prompts.dir", "")
 }
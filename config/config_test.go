@@ -0,0 +1,68 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigJiraAuth(t *testing.T) {
+	tests := []struct {
+		auth, baseURL, cloudID string
+		wantErr                string
+	}{
+		{auth: ""},
+		{auth: "basic", baseURL: "https://acme.atlassian.net"},
+		{auth: "pat", baseURL: "https://jira.acme.dev"},
+		{auth: "pat", baseURL: "https://acme.atlassian.net", wantErr: "pat is for Jira Server/Data Center"},
+		{auth: "oauth", cloudID: "11223344-a1b2-3b33-c444-def123456789"},
+		{auth: "oauth", wantErr: "needs plugins.jira.cloud_id"},
+		{auth: "oath", cloudID: "x", wantErr: "invalid plugins.jira.auth: oath"},
+		{auth: "Basic", wantErr: "invalid plugins.jira.auth"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.auth+" "+tc.baseURL, func(t *testing.T) {
+			var cfg Config
+			cfg.Reminder.Time = "17:00"
+			cfg.Plugins.Jira.Auth = tc.auth
+			cfg.Plugins.Jira.BaseURL = tc.baseURL
+			cfg.Plugins.Jira.CloudID = tc.cloudID
+
+			err := ValidateConfig(&cfg)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("ValidateConfig: unexpected error %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("ValidateConfig error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfigIfPresent(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	if err := LoadConfigIfPresent(); err != nil {
+		t.Fatalf("LoadConfigIfPresent without a config file = %v", err)
+	}
+	if _, err := os.Stat("config.yaml"); !os.IsNotExist(err) {
+		t.Errorf("config.yaml was created (stat err %v)", err)
+	}
+	if ConfigData.Reminder.Time == "" {
+		t.Error("defaults were not applied")
+	}
+
+	if err := os.WriteFile("config.yaml", []byte("reminder:\n  time: \"25:00\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfigIfPresent(); err == nil || !strings.Contains(err.Error(), "invalid reminder time") {
+		t.Errorf("LoadConfigIfPresent with an invalid config = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Package adf converts between Markdown and the Atlassian Document Format,
+// the JSON rich-text format Jira Cloud's REST API v3 uses for issue
+// descriptions and comments.
+package adf
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Node is an ADF node. A document is a Node of type "doc" whose content is
+// block nodes (paragraph, heading, bulletList, ...), which in turn hold
+// inline nodes (text, hardBreak, mention, ...).
+type Node struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version,omitempty"` // Only set on the doc node
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+	Content []*Node        `json:"content,omitempty"`
+}
+
+// Mark is inline formatting applied to a text node, such as strong, em, code or link
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// Doc wraps block nodes in a version 1 document
+func Doc(blocks ...*Node) *Node {
+	if blocks == nil {
+		blocks = []*Node{}
+	}
+	return &Node{Type: "doc", Version: 1, Content: blocks}
+}
+
+// Parse decodes an ADF document
+func Parse(data []byte) (*Node, error) {
+	var doc Node
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid ADF document: %w", err)
+	}
+	return &doc, nil
+}
+
+// attrString returns a string attribute, or "" if it is missing
+func (n *Node) attrString(key string) string {
+	value, _ := n.Attrs[key].(string)
+	return value
+}
+
+// attrInt returns a numeric attribute, or fallback if it is missing
+func (n *Node) attrInt(key string, fallback int) int {
+	// JSON numbers decode as float64
+	if value, ok := n.Attrs[key].(float64); ok {
+		return int(value)
+	}
+	if value, ok := n.Attrs[key].(int); ok {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,330 @@
+package adf
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	rulePattern        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	bulletItemPattern  = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	orderedItemPattern = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+)
+
+// FromMarkdown converts Markdown to an ADF document. It understands the
+// subset people write in tickets: headings, paragraphs, bullet and numbered
+// lists (nested by indentation), fenced code blocks, block quotes, rules and
+// the inline styles **bold**, *italic*, ~~strike~~, `code` and [links](url).
+func FromMarkdown(markdown string) *Node {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	return Doc(parseBlocks(lines)...)
+}
+
+func parseBlocks(lines []string) []*Node {
+	var blocks []*Node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			language := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // Closing fence
+			block := &Node{Type: "codeBlock"}
+			if language != "" {
+				block.Attrs = map[string]any{"language": language}
+			}
+			if text := strings.Join(code, "\n"); text != "" {
+				block.Content = []*Node{{Type: "text", Text: text}}
+			}
+			blocks = append(blocks, block)
+
+		case headingPattern.MatchString(trimmed):
+			m := headingPattern.FindStringSubmatch(trimmed)
+			blocks = append(blocks, &Node{
+				Type:    "heading",
+				Attrs:   map[string]any{"level": len(m[1])},
+				Content: parseInline(m[2], nil),
+			})
+			i++
+
+		case rulePattern.MatchString(line):
+			blocks = append(blocks, &Node{Type: "rule"})
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(quote, " "))
+			}
+			blocks = append(blocks, &Node{Type: "blockquote", Content: parseBlocks(quoted)})
+
+		case bulletItemPattern.MatchString(line) || orderedItemPattern.MatchString(line):
+			var list *Node
+			list, i = parseList(lines, i)
+			blocks = append(blocks, list)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines) && startsParagraphLine(lines[i]); i++ {
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			blocks = append(blocks, &Node{Type: "paragraph", Content: paragraphInline(paragraph)})
+		}
+	}
+	return blocks
+}
+
+// startsParagraphLine reports whether line continues a paragraph rather than starting another block
+func startsParagraphLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed != "" && !strings.HasPrefix(trimmed, "```") && !strings.HasPrefix(trimmed, ">") &&
+		!headingPattern.MatchString(trimmed) && !rulePattern.MatchString(line) &&
+		!bulletItemPattern.MatchString(line) && !orderedItemPattern.MatchString(line)
+}
+
+// paragraphInline joins a paragraph's lines with hard breaks, keeping the line structure of the ticket text
+func paragraphInline(lines []string) []*Node {
+	var content []*Node
+	for i, line := range lines {
+		if i > 0 {
+			content = append(content, &Node{Type: "hardBreak"})
+		}
+		content = append(content, parseInline(line, nil)...)
+	}
+	return content
+}
+
+// parseList parses the list starting at lines[start]. Items are lines at the
+// first item's indentation; more deeply indented lines belong to the item above
+// and are parsed as its nested blocks. It returns the list and the next line index.
+func parseList(lines []string, start int) (*Node, int) {
+	indent, ordered, first := listItem(lines[start])
+
+	list := &Node{Type: "bulletList"}
+	if ordered {
+		list.Type = "orderedList"
+		if n, err := strconv.Atoi(first); err == nil && n != 1 {
+			list.Attrs = map[string]any{"order": n}
+		}
+	}
+
+	var item *Node
+	var nested []string
+	flush := func() {
+		if item == nil {
+			return
+		}
+		item.Content = append(item.Content, parseBlocks(nested)...)
+		list.Content = append(list.Content, item)
+		nested = nil
+	}
+
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if strings.TrimSpace(line) == "" {
+			// A blank line ends the list unless it continues with an indented or sibling item
+			if i+1 < len(lines) && leadingSpaces(lines[i+1]) > indent {
+				nested = append(nested, "")
+				continue
+			}
+			if i+1 < len(lines) {
+				if nextIndent, nextOrdered, _ := listItem(lines[i+1]); nextIndent == indent && nextOrdered == ordered {
+					continue
+				}
+			}
+			break
+		}
+
+		itemIndent, itemOrdered, _ := listItem(line)
+		switch {
+		case itemIndent == indent && itemOrdered == ordered:
+			flush()
+			item = &Node{Type: "listItem", Content: []*Node{{Type: "paragraph", Content: parseInline(listItemText(line), nil)}}}
+		case leadingSpaces(line) > indent:
+			nested = append(nested, strings.TrimPrefix(line, strings.Repeat(" ", indent+2)))
+		default:
+			flush()
+			return list, i
+		}
+	}
+	flush()
+	return list, i
+}
+
+// listItem returns a list item line's indentation, whether it is numbered, and its number.
+// Lines that are not list items report an indentation of -1.
+func listItem(line string) (int, bool, string) {
+	if m := orderedItemPattern.FindStringSubmatch(line); m != nil {
+		return len(m[1]), true, m[2]
+	}
+	if m := bulletItemPattern.FindStringSubmatch(line); m != nil {
+		return len(m[1]), false, ""
+	}
+	return -1, false, ""
+}
+
+func listItemText(line string) string {
+	if m := orderedItemPattern.FindStringSubmatch(line); m != nil {
+		return m[3]
+	}
+	return bulletItemPattern.FindStringSubmatch(line)[2]
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+// inlineDelimiters pairs Markdown emphasis delimiters with ADF marks, longest first
+var inlineDelimiters = []struct {
+	delimiter string
+	mark      string
+}{
+	{"**", "strong"},
+	{"__", "strong"},
+	{"~~", "strike"},
+	{"*", "em"},
+	{"_", "em"},
+}
+
+// parseInline converts inline Markdown into text nodes carrying marks
+func parseInline(text string, marks []Mark) []*Node {
+	var nodes []*Node
+	var plain strings.Builder
+	flushPlain := func() {
+		if plain.Len() > 0 {
+			nodes = append(nodes, textNode(plain.String(), marks))
+			plain.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		if strings.HasPrefix(rest, "`") {
+			if end := strings.Index(rest[1:], "`"); end > 0 {
+				flushPlain()
+				nodes = append(nodes, textNode(rest[1:1+end], codeMarks(marks)))
+				i += end + 2
+				continue
+			}
+		}
+
+		if strings.HasPrefix(rest, "[") {
+			if label, href, n, ok := parseLink(rest); ok {
+				flushPlain()
+				nodes = append(nodes, parseInline(label, withMark(marks, Mark{Type: "link", Attrs: map[string]any{"href": href}}))...)
+				i += n
+				continue
+			}
+		}
+
+		matched := false
+		for _, d := range inlineDelimiters {
+			if !strings.HasPrefix(rest, d.delimiter) || !canOpen(text, i, d.delimiter) {
+				continue
+			}
+			inner := rest[len(d.delimiter):]
+			end := closingDelimiter(inner, d.delimiter)
+			if end < 0 {
+				continue
+			}
+			flushPlain()
+			nodes = append(nodes, parseInline(inner[:end], withMark(marks, Mark{Type: d.mark}))...)
+			i += len(d.delimiter)*2 + end
+			matched = true
+			break
+		}
+		if matched {
+			continue
+		}
+
+		plain.WriteByte(text[i])
+		i++
+	}
+	flushPlain()
+	return nodes
+}
+
+// canOpen reports whether the delimiter at text[i] can start emphasis. As in
+// CommonMark it must be followed by a non-space and, so that snake_case and
+// 2*3*4 stay literal, must not follow a letter or digit.
+func canOpen(text string, i int, delimiter string) bool {
+	next, _ := utf8.DecodeRuneInString(text[i+len(delimiter):])
+	if next == utf8.RuneError || unicode.IsSpace(next) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	return !isWordRune(prev)
+}
+
+// closingDelimiter returns the index in inner of the first delimiter that can
+// close emphasis, one that follows a non-space and is not followed by a letter
+// or digit, or -1 if there is none
+func closingDelimiter(inner, delimiter string) int {
+	for from := 1; from < len(inner); {
+		end := strings.Index(inner[from:], delimiter)
+		if end < 0 {
+			return -1
+		}
+		end += from
+		prev, _ := utf8.DecodeLastRuneInString(inner[:end])
+		next, _ := utf8.DecodeRuneInString(inner[end+len(delimiter):])
+		if !unicode.IsSpace(prev) && !isWordRune(next) {
+			return end
+		}
+		from = end + 1
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// parseLink parses "[label](href)" at the start of text, returning how many bytes it spans
+func parseLink(text string) (label, href string, n int, ok bool) {
+	closeLabel := strings.Index(text, "](")
+	if closeLabel < 1 {
+		return "", "", 0, false
+	}
+	closeHref := strings.Index(text[closeLabel+2:], ")")
+	if closeHref < 1 {
+		return "", "", 0, false
+	}
+	return text[1:closeLabel], text[closeLabel+2 : closeLabel+2+closeHref], closeLabel + 3 + closeHref, true
+}
+
+func textNode(text string, marks []Mark) *Node {
+	return &Node{Type: "text", Text: text, Marks: marks}
+}
+
+// codeMarks returns the marks for a code span inside marks. ADF only allows
+// code alongside link, so any emphasis around the span is dropped.
+func codeMarks(marks []Mark) []Mark {
+	var kept []Mark
+	for _, mark := range marks {
+		if mark.Type == "link" {
+			kept = append(kept, mark)
+		}
+	}
+	return append(kept, Mark{Type: "code"})
+}
+
+// withMark returns marks plus mark without modifying marks
+func withMark(marks []Mark, mark Mark) []Mark {
+	return append(append([]Mark(nil), marks...), mark)
+}
@@ -0,0 +1,79 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+	}{
+		{"paragraphs", "First line\nsecond line\n\nNext paragraph"},
+		{"heading", "## Steps to reproduce"},
+		{"bullet list", "- one\n- two\n- three"},
+		{"ordered list", "3. three\n4. four"},
+		{"nested list", "- parent\n  - child\n    1. grandchild\n- sibling"},
+		{"code block", "```go\nfunc main() {\n\tfmt.Println(\"*not em*\")\n}\n```"},
+		{"code block without language", "```\nplain\n```"},
+		{"quote", "> quoted\n>\n> - item"},
+		{"rule", "above\n\n---\n\nbelow"},
+		{"marks", "**bold** *em* ~~strike~~ `code`"},
+		{"nested marks", "**bold *em* text** and ~~struck **strong**~~"},
+		{"link", "see [the docs](https://example.com/docs) first"},
+		{"link with marks", "[read **this** now](https://example.com) or **[that](https://example.org)**"},
+		{"code link", "[`make test`](https://example.com/ci)"},
+		{"adjacent links", "[a](https://a.example)[b](https://b.example)"},
+		{"intraword", "2*3*4 and snake_case_name stay literal"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := FromMarkdown(tc.markdown)
+			if got := Markdown(doc); got != tc.markdown {
+				data, _ := json.Marshal(doc)
+				t.Errorf("round trip:\n got %q\nwant %q\n adf %s", got, tc.markdown, data)
+			}
+		})
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	link := Mark{Type: "link", Attrs: map[string]any{"href": "https://example.com"}}
+	tests := []struct {
+		name string
+		text string
+		want []*Node
+	}{
+		{"intraword star", "2*3*4", []*Node{textNode("2*3*4", nil)}},
+		{"intraword underscore", "snake_case_name", []*Node{textNode("snake_case_name", nil)}},
+		{"spaced delimiter", "a * b * c", []*Node{textNode("a * b * c", nil)}},
+		{"closer before word", "*a*b", []*Node{textNode("*a*b", nil)}},
+		{"emphasis", "an *important* word", []*Node{
+			textNode("an ", nil),
+			textNode("important", []Mark{{Type: "em"}}),
+			textNode(" word", nil),
+		}},
+		{"punctuation around emphasis", "(*see*).", []*Node{
+			textNode("(", nil),
+			textNode("see", []Mark{{Type: "em"}}),
+			textNode(").", nil),
+		}},
+		{"code drops emphasis", "**run `make`**", []*Node{
+			textNode("run ", []Mark{{Type: "strong"}}),
+			textNode("make", []Mark{{Type: "code"}}),
+		}},
+		{"code keeps link", "**[`make`](https://example.com)**", []*Node{
+			textNode("make", []Mark{link, {Type: "code"}}),
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _ := json.Marshal(parseInline(tc.text, nil))
+			want, _ := json.Marshal(tc.want)
+			if string(got) != string(want) {
+				t.Errorf("parseInline(%q)\n got %s\nwant %s", tc.text, got, want)
+			}
+		})
+	}
+}
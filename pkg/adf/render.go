@@ -0,0 +1,222 @@
+package adf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders an ADF document (or any node) as Markdown. Nodes without a
+// Markdown equivalent degrade to their text: mentions become @Name, emoji their
+// shortcode, cards and media a link or placeholder, and tables pipe-separated rows.
+func Markdown(n *Node) string {
+	if n == nil {
+		return ""
+	}
+	var b strings.Builder
+	renderBlocks(&b, n.Content, "")
+	return strings.TrimSpace(b.String())
+}
+
+// renderBlocks writes block nodes separated by blank lines, each line prefixed
+// with indent (used for nesting inside list items and quotes)
+func renderBlocks(b *strings.Builder, blocks []*Node, indent string) {
+	for i, block := range blocks {
+		if i > 0 {
+			b.WriteString(strings.TrimRight(indent, " ") + "\n")
+		}
+		renderBlock(b, block, indent)
+	}
+}
+
+func renderBlock(b *strings.Builder, n *Node, indent string) {
+	switch n.Type {
+	case "paragraph":
+		writeLines(b, inlineMarkdown(n.Content), indent)
+	case "heading":
+		writeLines(b, strings.Repeat("#", n.attrInt("level", 1))+" "+inlineMarkdown(n.Content), indent)
+	case "bulletList", "orderedList":
+		number := n.attrInt("order", 1)
+		for _, item := range n.Content {
+			marker := "- "
+			if n.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", number)
+				number++
+			}
+			renderListItem(b, item, indent, marker)
+		}
+	case "codeBlock":
+		writeLines(b, "```"+n.attrString("language"), indent)
+		writeLines(b, plainText(n.Content), indent)
+		writeLines(b, "```", indent)
+	case "blockquote":
+		renderBlocks(b, n.Content, indent+"> ")
+	case "rule":
+		writeLines(b, "---", indent)
+	case "table":
+		for _, row := range n.Content {
+			var cells []string
+			for _, cell := range row.Content {
+				var cb strings.Builder
+				renderBlocks(&cb, cell.Content, "")
+				cells = append(cells, strings.ReplaceAll(strings.TrimSpace(cb.String()), "\n", " "))
+			}
+			writeLines(b, "| "+strings.Join(cells, " | ")+" |", indent)
+		}
+	case "mediaSingle", "mediaGroup":
+		writeLines(b, "[attachment]", indent)
+	default:
+		// panel, expand, layouts and future block types: keep whatever they contain
+		if len(n.Content) > 0 && isBlock(n.Content[0]) {
+			renderBlocks(b, n.Content, indent)
+		} else {
+			writeLines(b, inlineMarkdown(n.Content), indent)
+		}
+	}
+}
+
+// renderListItem writes an item with its first block after marker and any
+// further blocks (such as nested lists) indented beneath it
+func renderListItem(b *strings.Builder, item *Node, indent, marker string) {
+	// Items are rendered tight: a nested list follows its item's text directly
+	var inner strings.Builder
+	for _, block := range item.Content {
+		renderBlock(&inner, block, "")
+	}
+	lines := strings.Split(strings.TrimRight(inner.String(), "\n"), "\n")
+
+	pad := strings.Repeat(" ", len(marker))
+	for i, line := range lines {
+		switch {
+		case i == 0:
+			b.WriteString(indent + marker + line + "\n")
+		case line == "":
+			b.WriteString("\n")
+		default:
+			b.WriteString(indent + pad + line + "\n")
+		}
+	}
+}
+
+func writeLines(b *strings.Builder, text, indent string) {
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(indent + line + "\n")
+	}
+}
+
+func isBlock(n *Node) bool {
+	switch n.Type {
+	case "text", "hardBreak", "mention", "emoji", "inlineCard", "status", "date", "inlineExtension":
+		return false
+	}
+	return true
+}
+
+// inlineMarkdown renders inline nodes, wrapping text in the Markdown for its
+// marks. A mark shared by neighbouring text nodes stays open across them, so
+// the nodes of **bold *em* text** render as written rather than as three
+// separately wrapped runs.
+func inlineMarkdown(nodes []*Node) string {
+	var b strings.Builder
+	var open []Mark // Outermost first
+	closeTo := func(depth int) {
+		for len(open) > depth {
+			b.WriteString(closeMark(open[len(open)-1]))
+			open = open[:len(open)-1]
+		}
+	}
+
+	for _, n := range nodes {
+		if n.Type != "text" {
+			closeTo(0)
+		}
+		switch n.Type {
+		case "text":
+			depth := 0
+			for depth < len(open) && hasMark(n.Marks, open[depth]) {
+				depth++
+			}
+			closeTo(depth)
+			for _, mark := range n.Marks {
+				if openMark(mark) != "" && !hasMark(open, mark) {
+					b.WriteString(openMark(mark))
+					open = append(open, mark)
+				}
+			}
+			if hasMark(n.Marks, Mark{Type: "code"}) {
+				b.WriteString("`" + n.Text + "`")
+			} else {
+				b.WriteString(n.Text)
+			}
+		case "hardBreak":
+			b.WriteString("\n")
+		case "mention":
+			name := strings.TrimPrefix(n.attrString("text"), "@")
+			b.WriteString("@" + name)
+		case "emoji":
+			b.WriteString(n.attrString("shortName"))
+		case "inlineCard":
+			b.WriteString(n.attrString("url"))
+		case "status":
+			b.WriteString("[" + n.attrString("text") + "]")
+		case "date":
+			b.WriteString(n.attrString("timestamp"))
+		default:
+			b.WriteString(inlineMarkdown(n.Content))
+		}
+	}
+	closeTo(0)
+	return b.String()
+}
+
+// openMark returns the Markdown that starts mark, or "" for marks Markdown
+// cannot express (and code, which wraps each text node on its own)
+func openMark(mark Mark) string {
+	switch mark.Type {
+	case "strong":
+		return "**"
+	case "em":
+		return "*"
+	case "strike":
+		return "~~"
+	case "link":
+		if mark.href() != "" {
+			return "["
+		}
+	}
+	return ""
+}
+
+func closeMark(mark Mark) string {
+	if mark.Type == "link" {
+		return "](" + mark.href() + ")"
+	}
+	return openMark(mark)
+}
+
+// hasMark reports whether marks contains mark; links match only the same href
+func hasMark(marks []Mark, mark Mark) bool {
+	for _, m := range marks {
+		if m.Type == mark.Type && m.href() == mark.href() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Mark) href() string {
+	href, _ := m.Attrs["href"].(string)
+	return href
+}
+
+// plainText concatenates the text of nodes without any formatting
+func plainText(nodes []*Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		if n.Type == "hardBreak" {
+			b.WriteString("\n")
+		}
+		b.WriteString(n.Text)
+		b.WriteString(plainText(n.Content))
+	}
+	return b.String()
+}
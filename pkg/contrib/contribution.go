@@ -0,0 +1,102 @@
+package contrib
+
+import (
+	"strings"
+	"time"
+)
+
+// Source identifies the system a contribution was collected from
+type Source string
+
+const (
+	SourceGitHub Source = "github"
+	SourceJira   Source = "jira"
+)
+
+// Kind describes what sort of work a contribution represents
+type Kind string
+
+const (
+	KindPullRequest   Kind = "pull_request"
+	KindCommit        Kind = "commit"
+	KindIssue         Kind = "issue"
+	KindReview        Kind = "review"         // A submitted PR review: approval, change request or comment
+	KindReviewComment Kind = "review_comment" // An inline comment on a PR diff
+	KindComment       Kind = "comment"        // A conversation comment on an issue or PR
+)
+
+// Identity is a person as known to a source. Any of the fields may be empty.
+type Identity struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+	Login string `json:"login,omitempty"` // GitHub login or Jira account ID
+}
+
+// Matches reports whether two identities refer to the same person, comparing
+// emails case-insensitively and logins exactly. Empty fields never match.
+func (id Identity) Matches(other Identity) bool {
+	if id.Email != "" && strings.EqualFold(id.Email, other.Email) {
+		return true
+	}
+	return id.Login != "" && id.Login == other.Login
+}
+
+// Link points from one contribution to a related one, e.g. a commit to its PR
+type Link struct {
+	Rel    string `json:"rel"`
+	Target string `json:"target"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Contribution is the source-agnostic record every plugin produces
+type Contribution struct {
+	Source    Source         `json:"source"`
+	Kind      Kind           `json:"kind"`
+	ID        string         `json:"id"`      // PR number, commit SHA or issue key
+	Project   string         `json:"project"` // owner/repo for GitHub, project key for Jira
+	Title     string         `json:"title"`
+	URL       string         `json:"url,omitempty"`
+	Authors   []Identity     `json:"authors,omitempty"`
+	Status    string         `json:"status,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	ClosedAt  time.Time      `json:"closed_at"`
+	Links     []Link         `json:"links,omitempty"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+}
+
+// Key uniquely identifies a contribution across sources, so re-fetching the
+// same item replaces the stored copy instead of duplicating it
+func (c Contribution) Key() string {
+	return string(c.Source) + "/" + string(c.Kind) + "/" + c.Project + "/" + c.ID
+}
+
+// AuthoredBy reports whether any of the contribution's authors matches one of ids
+func (c Contribution) AuthoredBy(ids []Identity) bool {
+	for _, author := range c.Authors {
+		for _, id := range ids {
+			if author.Matches(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MetadataStrings returns a string list stored in Metadata, whether it was set
+// in memory ([]string) or read back from JSON ([]any)
+func (c Contribution) MetadataStrings(key string) []string {
+	switch values := c.Metadata[key].(type) {
+	case []string:
+		return values
+	case []any:
+		strs := make([]string, 0, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package contrib
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	pr := Contribution{Source: SourceGitHub, Kind: KindPullRequest, Project: "acme/api", ID: "12"}
+	if got := pr.Key(); got != "github/pull_request/acme/api/12" {
+		t.Errorf("Key = %q", got)
+	}
+
+	// The same number in another repo, or as another kind, is a different contribution
+	otherRepo, review := pr, pr
+	otherRepo.Project = "acme/web"
+	review.Kind = KindReview
+	if pr.Key() == otherRepo.Key() || pr.Key() == review.Key() {
+		t.Errorf("keys collide: %q, %q, %q", pr.Key(), otherRepo.Key(), review.Key())
+	}
+}
+
+func TestAuthoredBy(t *testing.T) {
+	c := Contribution{Authors: []Identity{
+		{Name: "Dana", Email: "Dana@Example.com"},
+		{Login: "octocat"},
+	}}
+
+	tests := []struct {
+		name string
+		ids  []Identity
+		want bool
+	}{
+		{name: "email in another case", ids: []Identity{{Email: "dana@example.com"}}, want: true},
+		{name: "login", ids: []Identity{{Email: "me@example.com"}, {Login: "octocat"}}, want: true},
+		{name: "login differs in case", ids: []Identity{{Login: "OctoCat"}}},
+		{name: "name alone", ids: []Identity{{Name: "Dana"}}},
+		{name: "empty identity", ids: []Identity{{}}},
+		{name: "no identities"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := c.AuthoredBy(tc.ids); got != tc.want {
+				t.Errorf("AuthoredBy(%+v) = %v, want %v", tc.ids, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMetadataStrings(t *testing.T) {
+	var decoded Contribution
+	if err := json.Unmarshal([]byte(`{"metadata":{"credit":["reported",3,"commented (1)"],"epic":"CS-1"}}`), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	inMemory := Contribution{Metadata: map[string]any{"credit": []string{"reported"}}}
+
+	tests := []struct {
+		name string
+		c    Contribution
+		key  string
+		want []string
+	}{
+		{name: "in memory", c: inMemory, key: "credit", want: []string{"reported"}},
+		{name: "from JSON, skipping non-strings", c: decoded, key: "credit", want: []string{"reported", "commented (1)"}},
+		{name: "not a list", c: decoded, key: "epic"},
+		{name: "missing", c: decoded, key: "sprints"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.c.MetadataStrings(tc.key); !slices.Equal(got, tc.want) {
+				t.Errorf("MetadataStrings(%q) = %q, want %q", tc.key, got, tc.want)
+			}
+		})
+	}
+}
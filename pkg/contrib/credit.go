@@ -0,0 +1,63 @@
+package contrib
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Credit reasons the Jira plugin records in an issue's "credit" metadata. The
+// prefixed ones are followed by a detail: the done status, the comment count
+// or the time logged, e.g. "moved to Done", "commented (2)" or "logged 1h30m".
+const (
+	CreditReported             = "reported"
+	CreditMovedTo              = "moved to "
+	CreditAssigneeWhenResolved = "assignee when resolved"
+	CreditTransitioned         = "transitioned"
+	CreditCommented            = "commented "
+	CreditLogged               = "logged "
+)
+
+// MergeCredit combines the credit reasons stored for an issue with those from a
+// later fetch. An incremental sync only sees activity since its cursor, so the
+// later reasons alone would forget that the user moved the issue to done before
+// commenting on it again. A later reason replaces an earlier one of the same
+// kind; for comment counts and time logged the larger is kept, as summing would
+// double count the activity seen by overlapping fetches.
+func MergeCredit(earlier, later []string) []string {
+	merged := slices.Clone(earlier)
+	for _, reason := range later {
+		i := slices.IndexFunc(merged, func(r string) bool { return creditKind(r) == creditKind(reason) })
+		switch {
+		case i < 0:
+			merged = append(merged, reason)
+		case creditAmount(reason) >= creditAmount(merged[i]):
+			merged[i] = reason
+		}
+	}
+	return merged
+}
+
+// creditKind strips a reason's detail, e.g. "commented (2)" becomes "commented "
+func creditKind(reason string) string {
+	for _, prefix := range []string{CreditMovedTo, CreditCommented, CreditLogged} {
+		if strings.HasPrefix(reason, prefix) {
+			return prefix
+		}
+	}
+	return reason
+}
+
+// creditAmount returns the comment count or time logged a reason records, or 0
+func creditAmount(reason string) int64 {
+	switch creditKind(reason) {
+	case CreditCommented:
+		n, _ := strconv.ParseInt(strings.Trim(strings.TrimPrefix(reason, CreditCommented), "()"), 10, 64)
+		return n
+	case CreditLogged:
+		d, _ := time.ParseDuration(strings.TrimPrefix(reason, CreditLogged))
+		return int64(d)
+	}
+	return 0
+}
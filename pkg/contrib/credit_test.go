@@ -0,0 +1,29 @@
+package contrib
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeCredit(t *testing.T) {
+	tests := []struct {
+		name           string
+		earlier, later []string
+		want           []string
+	}{
+		{name: "nothing stored", later: []string{"commented (1)"}, want: []string{"commented (1)"}},
+		{name: "delivery kept", earlier: []string{"moved to Done"}, later: []string{"commented (1)"}, want: []string{"moved to Done", "commented (1)"}},
+		{name: "later status wins", earlier: []string{"moved to Done", "reported"}, later: []string{"moved to Closed"}, want: []string{"moved to Closed", "reported"}},
+		{name: "larger count kept", earlier: []string{"commented (3)"}, later: []string{"commented (1)"}, want: []string{"commented (3)"}},
+		{name: "larger count replaces", earlier: []string{"commented (3)"}, later: []string{"commented (4)"}, want: []string{"commented (4)"}},
+		{name: "more time logged", earlier: []string{"logged 45m"}, later: []string{"logged 1h30m"}, want: []string{"logged 1h30m"}},
+		{name: "less time logged", earlier: []string{"logged 2h"}, later: []string{"logged 30m"}, want: []string{"logged 2h"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MergeCredit(tc.earlier, tc.later); !slices.Equal(got, tc.want) {
+				t.Errorf("MergeCredit(%v, %v) = %v, want %v", tc.earlier, tc.later, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package daterange
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Range is a span of time; a zero Since or Until leaves that side open
+type Range struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Parse builds a Range from --since/--until style flag values relative to now.
+// See ParseAt for the accepted forms.
+func Parse(since, until string) (Range, error) {
+	return ParseAt(since, until, time.Now())
+}
+
+// ParseAt builds a Range from --since/--until style flag values. Either may be
+// empty. Accepted forms are dates (2026-07-01), RFC 3339 timestamps, durations
+// back from now (30d, 2w, 6m, 1y) and named periods (today, yesterday,
+// this-week, last-week, this-month, last-month, this-quarter, last-quarter,
+// this-year, last-year). Until is inclusive, so a bare date or period covers
+// all of it. A named period given only as --since also ends the range.
+func ParseAt(since, until string, now time.Time) (Range, error) {
+	var r Range
+
+	if since != "" {
+		p, err := parseValue(since, now)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid --since value: %w", err)
+		}
+		r.Since = p.Since
+		if until == "" && p.period {
+			r.Until = p.Until
+		}
+	}
+
+	if until != "" {
+		p, err := parseValue(until, now)
+		if err != nil {
+			return Range{}, fmt.Errorf("invalid --until value: %w", err)
+		}
+		r.Until = p.Until
+	}
+
+	if !r.Since.IsZero() && !r.Until.IsZero() && r.Until.Before(r.Since) {
+		return Range{}, fmt.Errorf("--until (%s) is before --since (%s)", until, since)
+	}
+	return r, nil
+}
+
+// String renders the range for headings, e.g. "2026-07-01 → 2026-09-30"
+func (r Range) String() string {
+	format := func(t time.Time, open string) string {
+		if t.IsZero() {
+			return open
+		}
+		return t.Format(time.DateOnly)
+	}
+	return format(r.Since, "beginning") + " → " + format(r.Until, "now")
+}
+
+// parsed is a single flag value. Since and Until are where the value starts
+// and ends; period marks named periods, which bound both sides of a range.
+type parsed struct {
+	Range
+	period bool
+}
+
+var relative = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+func parseValue(value string, now time.Time) (parsed, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	if m := relative.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		var t time.Time
+		switch m[2] {
+		case "d":
+			t = now.AddDate(0, 0, -n)
+		case "w":
+			t = now.AddDate(0, 0, -7*n)
+		case "m":
+			t = now.AddDate(0, -n, 0)
+		case "y":
+			t = now.AddDate(-n, 0, 0)
+		}
+		return parsed{Range: Range{Since: t, Until: t}}, nil
+	}
+
+	if start, end, ok := namedPeriod(value, today); ok {
+		return parsed{Range: Range{Since: start, Until: end.Add(-time.Nanosecond)}, period: true}, nil
+	}
+
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return parsed{Range: Range{Since: t, Until: t.AddDate(0, 0, 1).Add(-time.Nanosecond)}}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		return parsed{Range: Range{Since: t, Until: t}}, nil
+	}
+	return parsed{}, fmt.Errorf("%q is not a date (expected YYYY-MM-DD, 30d, last-quarter, ...)", value)
+}
+
+// namedPeriod returns the [start, end) of a named calendar period containing or preceding today
+func namedPeriod(name string, today time.Time) (time.Time, time.Time, bool) {
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7)) // Weeks start on Monday
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+	quarterStart := time.Date(today.Year(), today.Month()-(today.Month()-1)%3, 1, 0, 0, 0, 0, time.Local)
+	yearStart := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.Local)
+
+	switch name {
+	case "today":
+		return today, today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, true
+	case "this-week":
+		return weekStart, weekStart.AddDate(0, 0, 7), true
+	case "last-week":
+		return weekStart.AddDate(0, 0, -7), weekStart, true
+	case "this-month":
+		return monthStart, monthStart.AddDate(0, 1, 0), true
+	case "last-month":
+		return monthStart.AddDate(0, -1, 0), monthStart, true
+	case "this-quarter":
+		return quarterStart, quarterStart.AddDate(0, 3, 0), true
+	case "last-quarter":
+		return quarterStart.AddDate(0, -3, 0), quarterStart, true
+	case "this-year":
+		return yearStart, yearStart.AddDate(1, 0, 0), true
+	case "last-year":
+		return yearStart.AddDate(-1, 0, 0), yearStart, true
+	}
+	return time.Time{}, time.Time{}, false
+}
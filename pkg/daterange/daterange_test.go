@@ -0,0 +1,120 @@
+package daterange
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// now is a Wednesday afternoon in Q3
+var now = time.Date(2026, 8, 12, 15, 30, 0, 0, time.Local)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.Local)
+}
+
+// endOf returns the last instant of the given day, which an inclusive --until ends on
+func endOf(year int, month time.Month, d int) time.Time {
+	return day(year, month, d+1).Add(-time.Nanosecond)
+}
+
+func TestParseAt(t *testing.T) {
+	tests := []struct {
+		since, until string
+		want         Range
+	}{
+		{since: "", until: "", want: Range{}},
+		{since: "30d", want: Range{Since: now.AddDate(0, 0, -30)}},
+		{since: "2w", want: Range{Since: time.Date(2026, 7, 29, 15, 30, 0, 0, time.Local)}},
+		{since: "6m", want: Range{Since: time.Date(2026, 2, 12, 15, 30, 0, 0, time.Local)}},
+		{since: "1y", want: Range{Since: time.Date(2025, 8, 12, 15, 30, 0, 0, time.Local)}},
+		{since: "30d", until: "7d", want: Range{Since: now.AddDate(0, 0, -30), Until: time.Date(2026, 8, 5, 15, 30, 0, 0, time.Local)}},
+		{since: "2026-07-01", want: Range{Since: day(2026, 7, 1)}},
+		{since: "2026-07-01", until: "2026-09-30", want: Range{Since: day(2026, 7, 1), Until: endOf(2026, 9, 30)}},
+		{until: "2026-07-01", want: Range{Until: endOf(2026, 7, 1)}},
+		{since: "2026-07-01T10:00:00Z", want: Range{Since: time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC)}},
+		{since: "today", want: Range{Since: day(2026, 8, 12), Until: endOf(2026, 8, 12)}},
+		{since: "yesterday", want: Range{Since: day(2026, 8, 11), Until: endOf(2026, 8, 11)}},
+		{since: "this-week", want: Range{Since: day(2026, 8, 10), Until: endOf(2026, 8, 16)}},
+		{since: "last-week", want: Range{Since: day(2026, 8, 3), Until: endOf(2026, 8, 9)}},
+		{since: "this-month", want: Range{Since: day(2026, 8, 1), Until: endOf(2026, 8, 31)}},
+		{since: "last-month", want: Range{Since: day(2026, 7, 1), Until: endOf(2026, 7, 31)}},
+		{since: "this-quarter", want: Range{Since: day(2026, 7, 1), Until: endOf(2026, 9, 30)}},
+		{since: "last-quarter", want: Range{Since: day(2026, 4, 1), Until: endOf(2026, 6, 30)}},
+		{since: " Last-Quarter ", want: Range{Since: day(2026, 4, 1), Until: endOf(2026, 6, 30)}},
+		{since: "this-year", want: Range{Since: day(2026, 1, 1), Until: endOf(2026, 12, 31)}},
+		{since: "last-year", want: Range{Since: day(2025, 1, 1), Until: endOf(2025, 12, 31)}},
+		// An explicit --until replaces the end of a named --since period
+		{since: "last-quarter", until: "today", want: Range{Since: day(2026, 4, 1), Until: endOf(2026, 8, 12)}},
+		{since: "2026-01-01", until: "last-month", want: Range{Since: day(2026, 1, 1), Until: endOf(2026, 7, 31)}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.since+"→"+tc.until, func(t *testing.T) {
+			got, err := ParseAt(tc.since, tc.until, now)
+			if err != nil {
+				t.Fatalf("ParseAt(%q, %q) error: %v", tc.since, tc.until, err)
+			}
+			if !got.Since.Equal(tc.want.Since) || !got.Until.Equal(tc.want.Until) {
+				t.Errorf("ParseAt(%q, %q) = %v .. %v, want %v .. %v",
+					tc.since, tc.until, got.Since, got.Until, tc.want.Since, tc.want.Until)
+			}
+		})
+	}
+}
+
+func TestParseAtQuarterBoundaries(t *testing.T) {
+	tests := []struct {
+		now       time.Time
+		wantSince time.Time
+		wantUntil time.Time
+	}{
+		{now: day(2026, 1, 1), wantSince: day(2025, 10, 1), wantUntil: endOf(2025, 12, 31)},
+		{now: day(2026, 3, 31), wantSince: day(2025, 10, 1), wantUntil: endOf(2025, 12, 31)},
+		{now: day(2026, 4, 1), wantSince: day(2026, 1, 1), wantUntil: endOf(2026, 3, 31)},
+		{now: day(2026, 12, 31), wantSince: day(2026, 7, 1), wantUntil: endOf(2026, 9, 30)},
+	}
+	for _, tc := range tests {
+		got, err := ParseAt("last-quarter", "", tc.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Since.Equal(tc.wantSince) || !got.Until.Equal(tc.wantUntil) {
+			t.Errorf("last-quarter on %s = %v, want %s → %s", tc.now.Format(time.DateOnly), got, tc.wantSince.Format(time.DateOnly), tc.wantUntil.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestParseAtErrors(t *testing.T) {
+	tests := []struct {
+		since, until string
+		wantErr      string
+	}{
+		{since: "soon", wantErr: "invalid --since value"},
+		{until: "2026-13-01", wantErr: "invalid --until value"},
+		{since: "30", wantErr: "invalid --since value"},
+		{since: "2026-09-01", until: "2026-08-01", wantErr: "is before --since"},
+		{since: "this-month", until: "last-month", wantErr: "is before --since"},
+	}
+	for _, tc := range tests {
+		_, err := ParseAt(tc.since, tc.until, now)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("ParseAt(%q, %q) error = %v, want %q", tc.since, tc.until, err, tc.wantErr)
+		}
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	tests := []struct {
+		r    Range
+		want string
+	}{
+		{r: Range{}, want: "beginning → now"},
+		{r: Range{Since: day(2026, 7, 1)}, want: "2026-07-01 → now"},
+		{r: Range{Since: day(2026, 7, 1), Until: endOf(2026, 9, 30)}, want: "2026-07-01 → 2026-09-30"},
+	}
+	for _, tc := range tests {
+		if got := tc.r.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	anthropicBaseURL      = "https://api.anthropic.com/v1"
+	anthropicVersion      = "2023-06-01"
+	anthropicDefaultModel = "claude-3-5-haiku-latest"
+	// anthropicMaxTokens is used when llm.max_tokens is 0, as the Messages API requires a limit
+	anthropicMaxTokens = 1024
+)
+
+// anthropic speaks the Messages API
+type anthropic struct {
+	options
+	key string
+}
+
+func newAnthropic(opts options, keyEnv string) (Provider, error) {
+	key, err := apiKey(keyEnv, "Anthropic", true)
+	if err != nil {
+		return nil, err
+	}
+	if opts.baseURL == "" {
+		opts.baseURL = anthropicBaseURL
+	}
+	if opts.model == "" {
+		opts.model = anthropicDefaultModel
+	}
+	return &anthropic{options: opts, key: key}, nil
+}
+
+func (a *anthropic) Name() string {
+	return ProviderAnthropic + " " + a.model
+}
+
+func (a *anthropic) Complete(ctx context.Context, req Request) (*Response, error) {
+	req = a.resolve(req)
+	if req.MaxTokens == 0 {
+		req.MaxTokens = anthropicMaxTokens
+	}
+
+	// The system prompt is a top-level parameter rather than a message
+	var system []string
+	var messages []map[string]string
+	for _, m := range req.Messages {
+		if m.Role == RoleSystem {
+			system = append(system, m.Content)
+			continue
+		}
+		messages = append(messages, map[string]string{"role": m.Role, "content": m.Content})
+	}
+	payload := map[string]any{
+		"model":       req.Model,
+		"messages":    messages,
+		"max_tokens":  req.MaxTokens,
+		"temperature": *req.Temperature,
+	}
+	if len(system) > 0 {
+		payload["system"] = strings.Join(system, "\n\n")
+	}
+
+	var result struct {
+		Model   string `json:"model"`
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		StopReason string `json:"stop_reason"`
+		Usage      struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+		} `json:"usage"`
+	}
+	headers := map[string]string{"x-api-key": a.key, "anthropic-version": anthropicVersion}
+	endpoint := strings.TrimSuffix(a.baseURL, "/") + "/messages"
+	if err := postJSON(ctx, a.client, endpoint, headers, payload, &result); err != nil {
+		return nil, fmt.Errorf("%s: %w", a.Name(), err)
+	}
+
+	var text strings.Builder
+	for _, block := range result.Content {
+		if block.Type == "text" {
+			text.WriteString(block.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return nil, fmt.Errorf("%s returned no completion", a.Name())
+	}
+
+	return &Response{
+		Text:         strings.TrimSpace(text.String()),
+		Model:        result.Model,
+		InputTokens:  result.Usage.InputTokens,
+		OutputTokens: result.Usage.OutputTokens,
+		Truncated:    result.StopReason == "max_tokens",
+	}, nil
+}
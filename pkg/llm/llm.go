@@ -0,0 +1,181 @@
+// Package llm talks to the language models that write csync's summaries.
+// Providers share one request shape so plugins don't care which is configured.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+// Provider names accepted in llm.provider
+const (
+	ProviderOpenAI           = "openai"
+	ProviderAzure            = "azure"
+	ProviderAnthropic        = "anthropic"
+	ProviderOpenAICompatible = "openai-compatible" // Ollama, llama.cpp, vLLM, LM Studio, ...
+)
+
+// Chat roles
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+type Message struct {
+	Role    string
+	Content string
+}
+
+// Prompt returns the messages for a single-turn request, leaving out an empty system prompt
+func Prompt(system, user string) []Message {
+	var messages []Message
+	if system != "" {
+		messages = append(messages, Message{Role: RoleSystem, Content: system})
+	}
+	return append(messages, Message{Role: RoleUser, Content: user})
+}
+
+// Request is a chat completion request. Zero Model, Temperature and
+// MaxTokens fall back to the llm section of the config.
+type Request struct {
+	Messages    []Message
+	Model       string
+	Temperature *float64
+	MaxTokens   int
+}
+
+type Response struct {
+	Text         string
+	Model        string
+	InputTokens  int
+	OutputTokens int
+	Truncated    bool // The model stopped at MaxTokens
+}
+
+// Provider is a chat model API
+type Provider interface {
+	// Name identifies the provider and model for logs and errors, e.g. "openai gpt-4o-mini"
+	Name() string
+	Complete(ctx context.Context, req Request) (*Response, error)
+}
+
+// New returns the provider configured in cfg, with its API key read from the
+// environment
+func New(cfg config.LLM) (Provider, error) {
+	opts := options{
+		model:       cfg.Model,
+		baseURL:     cfg.BaseURL,
+		temperature: cfg.Temperature,
+		maxTokens:   cfg.MaxTokens,
+		client:      &http.Client{Timeout: cfg.Timeout},
+	}
+
+	switch cfg.Provider {
+	case "", ProviderOpenAI:
+		return newOpenAI(opts, envOr(cfg.APIKeyEnv, "OPENAI_API_KEY"))
+	case ProviderAzure:
+		return newAzure(opts, envOr(cfg.APIKeyEnv, "AZURE_OPENAI_API_KEY"), cfg.Deployment, cfg.APIVersion)
+	case ProviderAnthropic:
+		return newAnthropic(opts, envOr(cfg.APIKeyEnv, "ANTHROPIC_API_KEY"))
+	case ProviderOpenAICompatible:
+		return newOpenAICompatible(opts, cfg.APIKeyEnv)
+	default:
+		return nil, fmt.Errorf("unknown llm.provider %q (expected openai, azure, anthropic or openai-compatible)", cfg.Provider)
+	}
+}
+
+// FromConfig returns the provider configured in config.yaml
+func FromConfig() (Provider, error) {
+	return New(config.ConfigData.LLM)
+}
+
+// options are the settings every provider shares
+type options struct {
+	model       string
+	baseURL     string
+	temperature float64
+	maxTokens   int
+	client      *http.Client
+}
+
+// resolve fills a request's unset parameters from the configured defaults
+func (o options) resolve(req Request) Request {
+	if req.Model == "" {
+		req.Model = o.model
+	}
+	if req.Temperature == nil {
+		t := o.temperature
+		req.Temperature = &t
+	}
+	if req.MaxTokens == 0 {
+		req.MaxTokens = o.maxTokens
+	}
+	return req
+}
+
+func envOr(name, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// apiKey reads the key from env, failing with a hint when it's required but unset
+func apiKey(env, provider string, required bool) (string, error) {
+	if env == "" {
+		return "", nil
+	}
+	key := os.Getenv(env)
+	if key == "" && required {
+		return "", fmt.Errorf("%s is not set. Please export your %s API key.", env, provider)
+	}
+	return key, nil
+}
+
+// postJSON sends payload to url and decodes a 200 response into result
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, payload, result any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("JSON marshaling error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Logger.Warn().Err(err).Msg("Failed to close response body")
+		}
+	}()
+	logger.Logger.Debug().Str("url", url).Int("status", resp.StatusCode).Dur("took", time.Since(start)).Msg("LLM request")
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("status: %s, response: %s", resp.Status, string(respBody))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to parse response: %v", err)
+	}
+	return nil
+}
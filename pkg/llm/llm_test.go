@@ -0,0 +1,204 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ibexmonj/ContribSync/config"
+)
+
+// fakeLLM answers every request with status and body and records the last request it got
+type fakeLLM struct {
+	status  int
+	body    string
+	path    string
+	query   string
+	header  http.Header
+	payload map[string]any
+}
+
+func (f *fakeLLM) start(t *testing.T) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.path, f.query, f.header = r.URL.Path, r.URL.RawQuery, r.Header.Clone()
+		f.payload = nil
+		if err := json.NewDecoder(r.Body).Decode(&f.payload); err != nil {
+			t.Errorf("bad request body: %v", err)
+		}
+		w.WriteHeader(f.status)
+		io.WriteString(w, f.body)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+const (
+	chatCompletion   = `{"model":"gpt-4o-mini","choices":[{"message":{"content":" Shipped it. "},"finish_reason":"stop"}],"usage":{"prompt_tokens":12,"completion_tokens":3}}`
+	anthropicMessage = `{"model":"claude-3-5-haiku","content":[{"type":"text","text":"Shipped it."}],"stop_reason":"max_tokens","usage":{"input_tokens":12,"output_tokens":3}}`
+)
+
+func complete(t *testing.T, cfg config.LLM) (*Response, error) {
+	t.Helper()
+	provider, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider.Complete(context.Background(), Request{Messages: Prompt("Be brief.", "Summarize my week")})
+}
+
+func TestOpenAI(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "sk-test")
+	fake := &fakeLLM{status: http.StatusOK, body: chatCompletion}
+	url := fake.start(t)
+
+	resp, err := complete(t, config.LLM{Provider: ProviderOpenAI, BaseURL: url + "/v1", APIKeyEnv: "CSYNC_TEST_KEY", MaxTokens: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.path != "/v1/chat/completions" {
+		t.Errorf("path = %q, want /v1/chat/completions", fake.path)
+	}
+	if got := fake.header.Get("Authorization"); got != "Bearer sk-test" {
+		t.Errorf("Authorization = %q, want Bearer sk-test", got)
+	}
+	if fake.payload["model"] != openAIDefaultModel || fake.payload["max_tokens"] != float64(200) {
+		t.Errorf("payload = %v, want the default model and max_tokens 200", fake.payload)
+	}
+	if messages, _ := fake.payload["messages"].([]any); len(messages) != 2 {
+		t.Errorf("messages = %v, want the system and user messages", fake.payload["messages"])
+	}
+	if resp.Text != "Shipped it." || resp.InputTokens != 12 || resp.OutputTokens != 3 || resp.Truncated {
+		t.Errorf("response = %+v", resp)
+	}
+}
+
+func TestAzure(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "azure-key")
+	fake := &fakeLLM{status: http.StatusOK, body: chatCompletion}
+	url := fake.start(t)
+
+	_, err := complete(t, config.LLM{Provider: ProviderAzure, BaseURL: url + "/", APIKeyEnv: "CSYNC_TEST_KEY",
+		Model: "gpt-4o", Deployment: "summaries prod", APIVersion: "2024-10-21"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.path != "/openai/deployments/summaries prod/chat/completions" {
+		t.Errorf("path = %q, want the deployment's chat completions", fake.path)
+	}
+	if fake.query != "api-version=2024-10-21" {
+		t.Errorf("query = %q, want api-version=2024-10-21", fake.query)
+	}
+	if got := fake.header.Get("api-key"); got != "azure-key" {
+		t.Errorf("api-key = %q, want azure-key", got)
+	}
+	if got := fake.header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q, want none", got)
+	}
+}
+
+func TestAzureDefaults(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "azure-key")
+	fake := &fakeLLM{status: http.StatusOK, body: chatCompletion}
+	url := fake.start(t)
+
+	if _, err := complete(t, config.LLM{Provider: ProviderAzure, BaseURL: url, APIKeyEnv: "CSYNC_TEST_KEY", Model: "gpt-4o"}); err != nil {
+		t.Fatal(err)
+	}
+	if fake.path != "/openai/deployments/gpt-4o/chat/completions" || fake.query != "api-version="+azureAPIVersion {
+		t.Errorf("requested %s?%s, want the model as deployment and the default api-version", fake.path, fake.query)
+	}
+
+	if _, err := New(config.LLM{Provider: ProviderAzure, APIKeyEnv: "CSYNC_TEST_KEY", Model: "gpt-4o"}); err == nil {
+		t.Error("New without base_url succeeded, want an error")
+	}
+}
+
+func TestAnthropic(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "sk-ant")
+	fake := &fakeLLM{status: http.StatusOK, body: anthropicMessage}
+	url := fake.start(t)
+
+	resp, err := complete(t, config.LLM{Provider: ProviderAnthropic, BaseURL: url, APIKeyEnv: "CSYNC_TEST_KEY"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fake.path != "/messages" {
+		t.Errorf("path = %q, want /messages", fake.path)
+	}
+	if got := fake.header.Get("x-api-key"); got != "sk-ant" {
+		t.Errorf("x-api-key = %q, want sk-ant", got)
+	}
+	if got := fake.header.Get("anthropic-version"); got != anthropicVersion {
+		t.Errorf("anthropic-version = %q, want %s", got, anthropicVersion)
+	}
+	if fake.payload["system"] != "Be brief." {
+		t.Errorf("system = %v, want the system prompt as a top-level field", fake.payload["system"])
+	}
+	messages, _ := fake.payload["messages"].([]any)
+	if len(messages) != 1 || messages[0].(map[string]any)["role"] != RoleUser {
+		t.Errorf("messages = %v, want only the user message", messages)
+	}
+	if fake.payload["max_tokens"] != float64(anthropicMaxTokens) {
+		t.Errorf("max_tokens = %v, want the %d default", fake.payload["max_tokens"], anthropicMaxTokens)
+	}
+	if resp.Text != "Shipped it." || !resp.Truncated {
+		t.Errorf("response = %+v, want the text and Truncated", resp)
+	}
+}
+
+func TestOpenAICompatibleWithoutKey(t *testing.T) {
+	fake := &fakeLLM{status: http.StatusOK, body: chatCompletion}
+	url := fake.start(t)
+
+	if _, err := complete(t, config.LLM{Provider: ProviderOpenAICompatible, BaseURL: url + "/v1", Model: "llama3.1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.header.Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q, want none without a key", got)
+	}
+	if fake.payload["model"] != "llama3.1" {
+		t.Errorf("model = %v, want llama3.1", fake.payload["model"])
+	}
+
+	t.Setenv("CSYNC_TEST_KEY", "local")
+	if _, err := complete(t, config.LLM{Provider: ProviderOpenAICompatible, BaseURL: url + "/v1", Model: "llama3.1", APIKeyEnv: "CSYNC_TEST_KEY"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.header.Get("Authorization"); got != "Bearer local" {
+		t.Errorf("Authorization = %q, want Bearer local", got)
+	}
+}
+
+func TestMissingKey(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "")
+	for _, provider := range []string{ProviderOpenAI, ProviderAnthropic} {
+		_, err := New(config.LLM{Provider: provider, APIKeyEnv: "CSYNC_TEST_KEY"})
+		if err == nil || !strings.Contains(err.Error(), "CSYNC_TEST_KEY is not set") {
+			t.Errorf("%s: New = %v, want a missing key error", provider, err)
+		}
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	t.Setenv("CSYNC_TEST_KEY", "key")
+	for _, provider := range []string{ProviderOpenAI, ProviderAzure, ProviderAnthropic, ProviderOpenAICompatible} {
+		t.Run(provider, func(t *testing.T) {
+			fake := &fakeLLM{status: http.StatusTooManyRequests, body: `{"error":{"message":"quota exceeded"}}`}
+			url := fake.start(t)
+
+			_, err := complete(t, config.LLM{Provider: provider, BaseURL: url, APIKeyEnv: "CSYNC_TEST_KEY", Model: "m"})
+			if err == nil {
+				t.Fatal("Complete succeeded, want an error")
+			}
+			for _, want := range []string{provider + " m", "429 Too Many Requests", "quota exceeded"} {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q doesn't mention %q", err, want)
+				}
+			}
+		})
+	}
+}
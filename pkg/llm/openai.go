@@ -0,0 +1,153 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+const (
+	openAIBaseURL      = "https://api.openai.com/v1"
+	openAIDefaultModel = "gpt-4o-mini"
+	azureAPIVersion    = "2024-06-01"
+)
+
+// openAI speaks the Chat Completions API, which OpenAI, Azure OpenAI and most
+// local servers share; they differ in the URL and how the key is sent
+type openAI struct {
+	options
+	name     string
+	endpoint string
+	headers  map[string]string
+}
+
+func newOpenAI(opts options, keyEnv string) (Provider, error) {
+	key, err := apiKey(keyEnv, "OpenAI", true)
+	if err != nil {
+		return nil, err
+	}
+	if opts.baseURL == "" {
+		opts.baseURL = openAIBaseURL
+	}
+	if opts.model == "" {
+		opts.model = openAIDefaultModel
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + key}
+	if org := os.Getenv("OPENAI_ORG"); org != "" {
+		headers["OpenAI-Organization"] = org
+	}
+	return &openAI{
+		options:  opts,
+		name:     ProviderOpenAI,
+		endpoint: strings.TrimSuffix(opts.baseURL, "/") + "/chat/completions",
+		headers:  headers,
+	}, nil
+}
+
+// newAzure targets a deployment on an Azure OpenAI resource. The deployment
+// defaults to the model name, as Azure deployments are often named after it.
+func newAzure(opts options, keyEnv, deployment, apiVersion string) (Provider, error) {
+	key, err := apiKey(keyEnv, "Azure OpenAI", true)
+	if err != nil {
+		return nil, err
+	}
+	if opts.baseURL == "" {
+		return nil, errors.New("llm.base_url must be set to your Azure OpenAI endpoint (https://<resource>.openai.azure.com)")
+	}
+	if deployment == "" {
+		deployment = opts.model
+	}
+	if deployment == "" {
+		return nil, errors.New("llm.deployment (or llm.model) must name your Azure OpenAI deployment")
+	}
+	if apiVersion == "" {
+		apiVersion = azureAPIVersion
+	}
+
+	return &openAI{
+		options: opts,
+		name:    ProviderAzure,
+		endpoint: fmt.Sprintf("%s/openai/deployments/%s/chat/completions?api-version=%s",
+			strings.TrimSuffix(opts.baseURL, "/"), url.PathEscape(deployment), url.QueryEscape(apiVersion)),
+		headers: map[string]string{"api-key": key},
+	}, nil
+}
+
+// newOpenAICompatible targets a local or self-hosted server, e.g. Ollama at
+// http://localhost:11434/v1. The key is optional since most don't check one.
+func newOpenAICompatible(opts options, keyEnv string) (Provider, error) {
+	key, err := apiKey(keyEnv, "LLM server", false)
+	if err != nil {
+		return nil, err
+	}
+	if opts.baseURL == "" {
+		return nil, errors.New("llm.base_url must be set for openai-compatible servers (e.g. http://localhost:11434/v1)")
+	}
+	if opts.model == "" {
+		return nil, errors.New("llm.model must be set for openai-compatible servers")
+	}
+
+	headers := make(map[string]string)
+	if key != "" {
+		headers["Authorization"] = "Bearer " + key
+	}
+	return &openAI{
+		options:  opts,
+		name:     ProviderOpenAICompatible,
+		endpoint: strings.TrimSuffix(opts.baseURL, "/") + "/chat/completions",
+		headers:  headers,
+	}, nil
+}
+
+func (o *openAI) Name() string {
+	return o.name + " " + o.model
+}
+
+func (o *openAI) Complete(ctx context.Context, req Request) (*Response, error) {
+	req = o.resolve(req)
+
+	messages := make([]map[string]string, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = map[string]string{"role": m.Role, "content": m.Content}
+	}
+	payload := map[string]any{
+		"model":       req.Model,
+		"messages":    messages,
+		"temperature": *req.Temperature,
+	}
+	if req.MaxTokens > 0 {
+		payload["max_tokens"] = req.MaxTokens
+	}
+
+	var result struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := postJSON(ctx, o.client, o.endpoint, o.headers, payload, &result); err != nil {
+		return nil, fmt.Errorf("%s: %w", o.Name(), err)
+	}
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("%s returned no completion", o.Name())
+	}
+
+	return &Response{
+		Text:         strings.TrimSpace(result.Choices[0].Message.Content),
+		Model:        result.Model,
+		InputTokens:  result.Usage.PromptTokens,
+		OutputTokens: result.Usage.CompletionTokens,
+		Truncated:    result.Choices[0].FinishReason == "length",
+	}, nil
+}
@@ -0,0 +1,34 @@
+package plugins
+
+import (
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/spf13/pflag"
+)
+
+// newFlagSet returns a flag set for parsing a plugin subcommand's arguments.
+// Flags may appear anywhere among the positional arguments.
+func newFlagSet(name string) *pflag.FlagSet {
+	return pflag.NewFlagSet(name, pflag.ContinueOnError)
+}
+
+// rangeFlags are the --since/--until flags shared by subcommands that fetch history
+type rangeFlags struct {
+	since string
+	until string
+}
+
+func addRangeFlags(fs *pflag.FlagSet) *rangeFlags {
+	f := &rangeFlags{}
+	fs.StringVar(&f.since, "since", "", "Only include items updated on or after this (YYYY-MM-DD, 30d, last-quarter, ...)")
+	fs.StringVar(&f.until, "until", "", "Only include items updated on or before this (YYYY-MM-DD, 7d, today, ...)")
+	return f
+}
+
+func (f *rangeFlags) parse() (daterange.Range, error) {
+	return daterange.Parse(f.since, f.until)
+}
+
+// addLimitFlag registers --limit, the most items a subcommand fetches (0 for no limit)
+func addLimitFlag(fs *pflag.FlagSet) *int {
+	return fs.Int("limit", 0, "Stop after this many items (default: fetch all)")
+}
@@ -4,145 +4,697 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
-	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v57/github"
-	"golang.org/x/oauth2"
 )
 
-type GitHubPlugin struct{}
+// Defaults used when the GitHub plugin settings are not configured
+const (
+	defaultMaxPRs      = 200 // PRs fetched per repo or search
+	defaultConcurrency = 4   // Parallel commit requests
+)
+
+type GitHubPlugin struct {
+	maxPRs      int
+	concurrency int
+	backend     string
+	hosts       []githubHost
+	truncated   bool // Set when a PR or issue list hit maxPRs, see warnTruncated
+}
 
 func (g *GitHubPlugin) Init() error {
-	logger.Logger.Info().Msg("✅ GitHub plugin initialized")
+	g.maxPRs = config.ConfigData.Plugins.GitHub.MaxPRs
+	g.concurrency = config.ConfigData.Plugins.GitHub.Concurrency
+	g.backend = config.ConfigData.Plugins.GitHub.Backend
+	g.hosts = loadGitHubHosts(&config.ConfigData)
+	logger.Logger.Info().
+		Int("max_prs", g.prLimit()).
+		Int("concurrency", g.workerLimit()).
+		Int("hosts", len(g.hosts)).
+		Str("backend", g.backend).
+		Msg("✅ GitHub plugin initialized")
 	return nil
 }
 
+func (g *GitHubPlugin) prLimit() int {
+	if g.maxPRs > 0 {
+		return g.maxPRs
+	}
+	return defaultMaxPRs
+}
+
+func (g *GitHubPlugin) workerLimit() int {
+	if g.concurrency > 0 {
+		return g.concurrency
+	}
+	return defaultConcurrency
+}
+
 func (g *GitHubPlugin) Info() (string, string) {
-	return "github", "GitHub Plugin: Fetch PRs and commits"
+	return "github", "GitHub Plugin: Fetch PRs, commits, issues and review activity"
 }
 
-func (g *GitHubPlugin) Execute(args []string) error {
-	if len(args) < 2 {
-		return errors.New("Usage: csync plugin exec github summary owner/repo [email]")
+const githubSummaryUsage = "Usage: csync plugin exec github summary [owner/repo] [email] [--author LOGIN|@me] [--reviewed-by LOGIN] " +
+	"[--involves LOGIN] [--org ORG]... [--merged] [--host NAME] [--since DATE] [--until DATE] [--max-prs N] [--concurrency N] [--backend rest|graphql]"
+
+func (g *GitHubPlugin) Execute(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(githubSummaryUsage)
 	}
 
-	if args[0] != "summary" {
+	switch args[0] {
+	case "summary":
+		return g.executeSummary(ctx, args[1:])
+	case "reviews":
+		return g.executeReviews(ctx, args[1:])
+	case "issues":
+		return g.executeIssues(ctx, args[1:])
+	default:
 		return fmt.Errorf("Unknown command for github: %s", args[0])
 	}
+}
+
+func (g *GitHubPlugin) executeSummary(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(githubSummaryUsage)
+	}
 
-	repoArg := args[1]
-	owner, repo, err := parseOwnerRepo(repoArg)
+	var search PRSearch
+	fs := newFlagSet("github summary")
+	dates := addRangeFlags(fs)
+	fs.StringVar(&search.Author, "author", "", "Search PRs opened by this login (@me for yourself) across repos")
+	fs.StringVar(&search.ReviewedBy, "reviewed-by", "", "Search PRs reviewed by this login")
+	fs.StringVar(&search.Involves, "involves", "", "Search PRs involving this login in any way")
+	fs.StringSliceVar(&search.Orgs, "org", nil, "Limit the search to these orgs (repeatable)")
+	fs.BoolVar(&search.MergedOnly, "merged", false, "Only merged PRs; the date range applies to the merge date")
+	fs.StringVar(&search.Host, "host", "", "Query this plugins.github.hosts entry instead of the default instance")
+	fs.IntVar(&g.maxPRs, "max-prs", g.maxPRs, "Stop after this many PRs per repo or search (default plugins.github.max_prs)")
+	fs.IntVar(&g.concurrency, "concurrency", g.concurrency, "Fetch commits for this many PRs at once (default plugins.github.concurrency)")
+	fs.StringVar(&g.backend, "backend", g.backend, "Fetch PRs and commits via rest or graphql (default plugins.github.backend)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, githubSummaryUsage)
+	}
+	r, err := dates.parse()
 	if err != nil {
 		return err
 	}
+	search.Since, search.Until = r.Since, r.Until
+	if g.backend != "" && g.backend != backendREST && g.backend != backendGraphQL {
+		return fmt.Errorf("invalid --backend %q (expected rest or graphql)", g.backend)
+	}
 
 	var emailFilter string
+	if fs.NArg() >= 1 {
+		repoArg := fs.Arg(0)
+		if _, _, err := parseOwnerRepo(repoArg); err != nil {
+			return err
+		}
+		search.Repos = []string{repoArg}
+
+		if fs.NArg() >= 2 {
+			emailFilter = fs.Arg(1)
+		}
+	}
+
+	if len(search.Repos) == 0 && !search.usesSearch() {
+		return errors.New(githubSummaryUsage)
+	}
+
+	return g.Summary(ctx, search, emailFilter)
+}
+
+// Fetch returns PRs and their commits for the query. Targets are owner/repo
+// or org names, prefixed with "<host>:" for repos on a configured extra host.
+// With a login identity, PRs authored by that login are found via search
+// across the targets (or, without targets, each host's configured repos, or
+// everywhere on a host without any), and the
+// login's review activity and issues are included; otherwise each repo is listed and,
+// when the query has emails, only PRs containing commits by those emails are kept.
+// If ctx is cancelled, the contributions gathered so far are returned with the error;
+// if a list hit max_prs or q.Limit, they are returned with ErrTruncated.
+func (g *GitHubPlugin) Fetch(ctx context.Context, q Query) ([]contrib.Contribution, error) {
+	targets, err := g.hostTargets(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var login string
+	for _, id := range q.Identities {
+		if id.Login != "" {
+			login = id.Login
+			break
+		}
+	}
+
+	g.truncated = false
+	var contributions []contrib.Contribution
+	queried := 0
+	for _, host := range g.hostList() {
+		hostLogin := login
+		if host.login != "" {
+			hostLogin = host.login
+		}
+
+		hostTargets, ok := targets[host.name]
+		if !ok && (len(q.Targets) > 0 || hostLogin == "") {
+			continue
+		}
+		queried++
 
-	if len(args) >= 3 {
-		emailFilter = args[2]
+		hostContributions, err := host.qualify(g.fetchHost(ctx, host, hostTargets, hostLogin, q))
+		contributions = append(contributions, hostContributions...)
+		if err != nil {
+			return q.finish(contributions, g.truncated, err)
+		}
 	}
 
-	return GitHubSummary(owner, repo, emailFilter)
+	if queried == 0 {
+		return nil, errors.New("github: an owner/repo or org target, or a login identity, is required")
+	}
+	return q.finish(contributions, g.truncated, nil)
 }
 
-// GitHubSummary fetches PRs & commits for a repo
-func GitHubSummary(owner, repo, emailFilter string) error {
-	token := os.Getenv("GITHUB_TOKEN")
-	if token == "" {
-		return errors.New("❌ GITHUB_TOKEN is not set")
+// hostTargets groups the query's targets by host name. A query without
+// targets falls back to the repos configured for each host, so a host's
+// plugins.github.hosts[].repos scopes what is fetched from it.
+func (g *GitHubPlugin) hostTargets(q Query) (map[string][]string, error) {
+	targets := make(map[string][]string)
+	for _, target := range q.Targets {
+		host, rest, err := g.splitTarget(target)
+		if err != nil {
+			return nil, err
+		}
+		targets[host.name] = append(targets[host.name], rest)
+	}
+	if len(q.Targets) > 0 {
+		return targets, nil
+	}
+
+	for _, host := range g.hostList() {
+		if len(host.repos) > 0 {
+			targets[host.name] = host.repos
+		}
 	}
+	return targets, nil
+}
 
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+// fetchHost collects the contributions on one host for Fetch
+func (g *GitHubPlugin) fetchHost(ctx context.Context, host githubHost, targets []string, login string, q Query) ([]contrib.Contribution, error) {
+	search := PRSearch{Host: host.name, Author: login, Since: q.Since, Until: q.Until}
+	for _, target := range targets {
+		if strings.Contains(target, "/") {
+			search.Repos = append(search.Repos, target)
+		} else {
+			search.Orgs = append(search.Orgs, target)
+		}
+	}
 
-	prs, err := fetchPRs(client, ctx, owner, repo)
+	client, err := g.newGitHubClient(ctx, host.name)
 	if err != nil {
-		return fmt.Errorf("❌ Failed to fetch PRs: %w", err)
+		return nil, err
 	}
 
+	// Authorship already came from the search, so commits are not filtered by email too
+	var emails []string
+	if search.Author == "" {
+		emails = q.Emails()
+	}
+
+	contributions, err := g.collectContributions(client, ctx, search, emails)
+	if err != nil || search.Author == "" {
+		return contributions, err
+	}
+
+	login, err = resolveLogin(client, ctx, search.Author)
+	if err != nil {
+		return contributions, err
+	}
+	activity, err := g.collectReviewActivity(client, ctx, search, login)
+	contributions = append(contributions, activity...)
+	if err != nil {
+		return contributions, err
+	}
+
+	issues, err := g.collectIssues(client, ctx, search, login)
+	return append(contributions, issues...), err
+}
+
+// Summary prints the PRs & commits selected by search, keeping only commits by
+// emailFilter if set. When the search is by author, their review activity and issues follow.
+func (g *GitHubPlugin) Summary(ctx context.Context, search PRSearch, emailFilter string) error {
+	host, err := g.host(search.Host)
+	if err != nil {
+		return err
+	}
+	client, err := g.newGitHubClient(ctx, search.Host)
+	if err != nil {
+		return err
+	}
+
+	var emails []string
 	if emailFilter != "" {
-		logger.Logger.Info().
-			Str("owner", owner).
-			Str("repo", repo).
-			Msg("📌 Pull Request Summary")
-	} else {
-		logger.Logger.Info().
-			Str("owner", owner).
-			Str("repo", repo).
-			Msg("📌 Pull Request Summary")
+		emails = []string{emailFilter}
 	}
-	prCount := 0
 
-	for _, pr := range prs {
+	logger.Logger.Info().
+		Str("scope", search.describe()).
+		Msg("📌 Pull Request Summary")
+
+	err = printSection(func() ([]contrib.Contribution, error) {
+		return host.qualify(g.collectContributions(client, ctx, search, emails))
+	}, printGitHubSummary)
+
+	if err == nil && search.Author != "" {
+		var login string
+		if login, err = resolveLogin(client, ctx, search.Author); err != nil {
+			return err
+		}
+
+		err = printSection(func() ([]contrib.Contribution, error) {
+			return host.qualify(g.collectReviewActivity(client, ctx, search, login))
+		}, func(activity []contrib.Contribution) { printReviewActivity(login, activity) })
 
-		commits, err := fetchCommits(client, ctx, owner, repo, *pr.Number)
+		if err == nil {
+			err = printSection(func() ([]contrib.Contribution, error) {
+				return host.qualify(g.collectIssues(client, ctx, search, login))
+			}, func(issues []contrib.Contribution) { printGitHubIssues(login, issues) })
+		}
+	}
+
+	if IsCancellation(err) {
+		fmt.Printf("\n⚠️ Stopped early (%v); showing partial results.\n", err)
+	}
+	return err
+}
+
+// printSection collects and prints one part of a summary. Partial results of a
+// cancelled collection are still printed before the error is returned.
+func printSection(collect func() ([]contrib.Contribution, error), print func([]contrib.Contribution)) error {
+	contributions, err := collect()
+	if err != nil && !IsCancellation(err) {
+		return err
+	}
+	print(contributions)
+	return err
+}
+
+// selectPRs resolves a PRSearch to pull requests: one search API query, or a
+// listing per repo. A warning is printed wherever the PR cap cut results short.
+func (g *GitHubPlugin) selectPRs(client *github.Client, ctx context.Context, search PRSearch) ([]*github.PullRequest, error) {
+	maxPRs := g.prLimit()
+
+	if search.usesSearch() {
+		prs, truncated, err := g.searchPRs(client, ctx, search.query(), maxPRs)
+		if truncated {
+			g.warnTruncated(search.describe(), maxPRs)
+		}
+		return prs, err
+	}
+
+	var prs []*github.PullRequest
+	for _, target := range search.Repos {
+		owner, repo, err := parseOwnerRepo(target)
+		if err != nil {
+			return prs, err
+		}
+
+		repoPRs, truncated, err := fetchPRs(client, ctx, owner, repo, maxPRs)
 		if err != nil {
-			fmt.Printf("   ⚠️ Failed to fetch commits for PR #%d: %v\n", *pr.Number, err)
+			return prs, err
+		}
+		if truncated {
+			g.warnTruncated(target, maxPRs)
+		}
+		prs = append(prs, repoPRs...)
+	}
+	return prs, nil
+}
+
+// warnTruncated tells the user a list hit the PR cap and records it, so Fetch
+// can report ErrTruncated
+func (g *GitHubPlugin) warnTruncated(scope string, maxPRs int) {
+	g.truncated = true
+	logger.Logger.Warn().Str("scope", scope).Int("max_prs", maxPRs).Msg("PR list truncated")
+	fmt.Printf("⚠️ %s has more than %d matching PRs; only the most recent %d are included. "+
+		"Raise plugins.github.max_prs (or --max-prs) or narrow the date range to see more.\n", scope, maxPRs, maxPRs)
+}
+
+// collectContributions returns each selected PR followed by its commits, keeping
+// only PRs in the search's date range and, if emails are given, with commits by them.
+// With the graphql backend a failed GraphQL fetch is retried over REST.
+// On cancellation it returns the contributions collected so far alongside ctx.Err().
+func (g *GitHubPlugin) collectContributions(client *github.Client, ctx context.Context, search PRSearch, emails []string) ([]contrib.Contribution, error) {
+	if g.backend == backendGraphQL {
+		contributions, err := g.collectContributionsGraphQL(client, ctx, search, emails)
+		if err == nil || IsCancellation(err) {
+			return contributions, err
+		}
+		useGraphQLFallback(err)
+	}
+	return g.collectContributionsREST(client, ctx, search, emails)
+}
+
+// collectContributionsREST lists PRs, then fetches each PR's commits
+// concurrently. PRs whose commits can't be listed are left out and the first
+// such failure is returned with the rest.
+func (g *GitHubPlugin) collectContributionsREST(client *github.Client, ctx context.Context, search PRSearch, emails []string) ([]contrib.Contribution, error) {
+	prs, err := g.selectPRs(client, ctx, search)
+	if err != nil {
+		if IsCancellation(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("❌ Failed to fetch PRs: %w", err)
+	}
+
+	window := Query{Since: search.Since, Until: search.Until}
+	selected := prs[:0]
+	for _, pr := range prs {
+		if search.MergedOnly || window.InRange(pr.GetUpdatedAt().Time) {
+			selected = append(selected, pr)
+		}
+	}
+
+	results := g.fetchCommitsConcurrently(client, ctx, selected)
+
+	var contributions []contrib.Contribution
+	var commitsErr error
+	for i, pr := range selected {
+		result := results[i]
+		if result.err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return contributions, ctxErr
+			}
+			// Keep collecting the other PRs, but fail the fetch so a sync doesn't move past this one
+			owner, repo := prOwnerRepo(pr)
+			logger.Logger.Warn().Err(result.err).Str("repo", owner+"/"+repo).Int("pr", pr.GetNumber()).Msg("Failed to fetch PR commits")
+			if commitsErr == nil {
+				commitsErr = fmt.Errorf("failed to fetch commits for %s/%s#%d: %w", owner, repo, pr.GetNumber(), result.err)
+			}
 			continue
 		}
 
-		var matchingCommits []*github.RepositoryCommit
-		if emailFilter != "" {
-			matchingCommits = filterCommitsByEmail(commits, emailFilter)
-			if len(matchingCommits) == 0 {
+		commits := result.commits
+		if len(emails) > 0 {
+			commits = filterCommitsByEmail(commits, emails...)
+			if len(commits) == 0 {
 				continue
 			}
-		} else {
-			matchingCommits = commits
 		}
 
-		prCount++
+		owner, repo := prOwnerRepo(pr)
+		prContribution := pullRequestContribution(owner, repo, pr)
+		contributions = append(contributions, prContribution)
+		for _, commit := range commits {
+			contributions = append(contributions, commitContribution(owner, repo, commit, prContribution))
+		}
+	}
+	return contributions, commitsErr
+}
+
+// commitsResult is the outcome of fetching one PR's commits
+type commitsResult struct {
+	commits []*github.RepositoryCommit
+	err     error
+}
+
+// fetchCommitsConcurrently fetches the commits of every PR using at most
+// g.concurrency requests at a time. results[i] belongs to prs[i], so output
+// order does not depend on which request finishes first.
+func (g *GitHubPlugin) fetchCommitsConcurrently(client *github.Client, ctx context.Context, prs []*github.PullRequest) []commitsResult {
+	results := make([]commitsResult, len(prs))
+	errs := g.forEachConcurrently(ctx, len(prs), func(i int) error {
+		owner, repo := prOwnerRepo(prs[i])
+		commits, err := fetchCommits(client, ctx, owner, repo, prs[i].GetNumber())
+		results[i].commits = commits
+		return err
+	})
+	for i, err := range errs {
+		results[i].err = err
+	}
+	return results
+}
+
+// forEachConcurrently calls work for each index below n, at most workerLimit
+// calls at a time, and returns the error of each call by index. Once ctx is
+// done no more calls start, and those that didn't report ctx.Err().
+func (g *GitHubPlugin) forEachConcurrently(ctx context.Context, n int, work func(i int) error) []error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, g.workerLimit())
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			for j := i; j < n; j++ {
+				errs[j] = ctx.Err()
+			}
+			wg.Wait()
+			return errs
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = work(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func prOwnerRepo(pr *github.PullRequest) (string, string) {
+	return pr.GetBase().GetRepo().GetOwner().GetLogin(), pr.GetBase().GetRepo().GetName()
+}
+
+func printGitHubSummary(contributions []contrib.Contribution) {
+	prCount := 0
+	printedCommits := true
 
-		fmt.Printf("\n🔹 **PR #%d**: %s (%s)\n", *pr.Number, *pr.Title, *pr.State)
-		fmt.Printf("   🏷️ Status: %s | 🔄 Merged: %v | 📆 Created: %v\n", *pr.State, pr.MergedAt != nil, pr.CreatedAt)
+	for _, c := range contributions {
+		switch c.Kind {
+		case contrib.KindPullRequest:
+			if !printedCommits {
+				fmt.Println("   🚫 No matching commits found.")
+			}
+			prCount++
+			printedCommits = false
 
-		if len(matchingCommits) > 0 {
-			fmt.Println("   📝 Commits:")
-			for _, commit := range matchingCommits {
-				fmt.Printf("      - [%s] %s\n", (*commit.SHA)[:7], *commit.Commit.Message)
+			merged, _ := c.Metadata["merged"].(bool)
+			fmt.Printf("\n🔹 **PR #%s**: %s (%s)\n", c.ID, c.Title, c.Status)
+			fmt.Printf("   🏷️ Status: %s | 🔄 Merged: %v | 📆 Created: %v\n", c.Status, merged, c.CreatedAt)
+		case contrib.KindCommit:
+			if !printedCommits {
+				fmt.Println("   📝 Commits:")
+				printedCommits = true
 			}
-		} else {
-			fmt.Println("   🚫 No matching commits found.")
+			fmt.Printf("      - [%s] %s\n", shortSHA(c.ID), c.Title)
 		}
 	}
+	if !printedCommits {
+		fmt.Println("   🚫 No matching commits found.")
+	}
 
 	if prCount == 0 {
 		fmt.Println("\n❌ No pull requests found.")
 	}
-	return nil
 }
 
-func filterCommitsByEmail(commits []*github.RepositoryCommit, email string) []*github.RepositoryCommit {
+func pullRequestContribution(owner, repo string, pr *github.PullRequest) contrib.Contribution {
+	var authors []contrib.Identity
+	if pr.User != nil {
+		authors = append(authors, contrib.Identity{Name: pr.User.GetName(), Email: pr.User.GetEmail(), Login: pr.User.GetLogin()})
+	}
+
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindPullRequest,
+		ID:        strconv.Itoa(pr.GetNumber()),
+		Project:   owner + "/" + repo,
+		Title:     pr.GetTitle(),
+		URL:       pr.GetHTMLURL(),
+		Authors:   authors,
+		Status:    pr.GetState(),
+		CreatedAt: pr.GetCreatedAt().Time,
+		UpdatedAt: pr.GetUpdatedAt().Time,
+		ClosedAt:  pr.GetClosedAt().Time,
+		Metadata: map[string]any{
+			"merged":    pr.MergedAt != nil,
+			"merged_at": pr.GetMergedAt().Time,
+			"branch":    pr.GetHead().GetRef(),
+		},
+	}
+}
+
+func commitContribution(owner, repo string, commit *github.RepositoryCommit, pr contrib.Contribution) contrib.Contribution {
+	author := contrib.Identity{
+		Name:  commit.GetCommit().GetAuthor().GetName(),
+		Email: commit.GetCommit().GetAuthor().GetEmail(),
+		Login: commit.GetAuthor().GetLogin(),
+	}
+	message := commit.GetCommit().GetMessage()
+	date := commit.GetCommit().GetAuthor().GetDate().Time
+
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindCommit,
+		ID:        commit.GetSHA(),
+		Project:   owner + "/" + repo,
+		Title:     strings.SplitN(message, "\n", 2)[0],
+		URL:       commit.GetHTMLURL(),
+		Authors:   []contrib.Identity{author},
+		CreatedAt: date,
+		UpdatedAt: commit.GetCommit().GetCommitter().GetDate().Time,
+		Links:     []contrib.Link{{Rel: "pull_request", Target: pr.ID, URL: pr.URL}},
+		Metadata:  map[string]any{"message": message},
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+func filterCommitsByEmail(commits []*github.RepositoryCommit, emails ...string) []*github.RepositoryCommit {
 	var filtered []*github.RepositoryCommit
 	for _, commit := range commits {
-		if commit.Commit.Author != nil && commit.Commit.Author.Email != nil && *commit.Commit.Author.Email == email {
+		if commit.Commit.Author != nil && commit.Commit.Author.Email != nil && slices.Contains(emails, *commit.Commit.Author.Email) {
 			filtered = append(filtered, commit)
 		}
 	}
 	return filtered
 }
 
-// Fetch PRs for the repository
-func fetchPRs(client *github.Client, ctx context.Context, owner, repo string) ([]*github.PullRequest, error) {
-	opts := &github.PullRequestListOptions{State: "all", ListOptions: github.ListOptions{PerPage: 10}}
-	prs, _, err := client.PullRequests.List(ctx, owner, repo, opts)
+// fetchPRs lists the repository's PRs, most recently updated first, following
+// pagination until maxPRs is reached. Date-ranged queries never get here:
+// usesSearch sends them to the search API, which applies the range itself.
+// truncated reports whether PRs were left unfetched.
+func fetchPRs(client *github.Client, ctx context.Context, owner, repo string, maxPRs int) (prs []*github.PullRequest, truncated bool, err error) {
+	opts := &github.PullRequestListOptions{
+		State:       "all",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: pageSize(maxPRs)},
+	}
+	for {
+		page, resp, err := client.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, false, fmt.Errorf("error fetching PRs from GitHub: %w", err)
+		}
+		for _, pr := range page {
+			if len(prs) == maxPRs {
+				return prs, true, nil
+			}
+			prs = append(prs, pr)
+		}
+
+		if resp.NextPage == 0 {
+			return prs, false, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// searchPRs runs an issue search and loads the full PR for every hit, since
+// search results lack PR-only fields such as the merge state and head branch.
+// At most workerLimit PRs are loaded at once.
+// PRs keep the search order; on error those before the first failed hit are returned.
+func (g *GitHubPlugin) searchPRs(client *github.Client, ctx context.Context, query string, maxPRs int) ([]*github.PullRequest, bool, error) {
+	issues, truncated, err := searchIssues(client, ctx, query, maxPRs)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching PRs from GitHub: %w", err)
+		return nil, false, err
 	}
-	return prs, nil
+
+	prs := make([]*github.PullRequest, len(issues))
+	errs := g.forEachConcurrently(ctx, len(issues), func(i int) error {
+		owner, repo, err := issueRepo(issues[i])
+		if err != nil {
+			return err
+		}
+		pr, _, err := client.PullRequests.Get(ctx, owner, repo, issues[i].GetNumber())
+		if err != nil {
+			return fmt.Errorf("error fetching PR %s/%s#%d from GitHub: %w", owner, repo, issues[i].GetNumber(), err)
+		}
+		prs[i] = pr
+		return nil
+	})
+
+	for i, err := range errs {
+		if err != nil {
+			return prs[:i], truncated, err
+		}
+	}
+	return prs, truncated, nil
+}
+
+// issueRepo extracts owner and repo from a search hit's repository_url
+func issueRepo(issue *github.Issue) (string, string, error) {
+	parts := strings.Split(strings.TrimSuffix(issue.GetRepositoryURL(), "/"), "/")
+	if len(parts) < 2 {
+		return "", "", fmt.Errorf("unexpected repository URL in search result: %q", issue.GetRepositoryURL())
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], nil
 }
 
-// Fetch commits for a specific PR
+// searchIssues pages through an issue/PR search, most recently updated first,
+// stopping after max results. The search API itself never returns more than 1000.
+func searchIssues(client *github.Client, ctx context.Context, query string, max int) ([]*github.Issue, bool, error) {
+	opts := &github.SearchOptions{Sort: "updated", Order: "desc", ListOptions: github.ListOptions{PerPage: pageSize(max)}}
+
+	var issues []*github.Issue
+	for {
+		result, resp, err := client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, false, fmt.Errorf("error searching GitHub: %w", err)
+		}
+		issues = append(issues, result.Issues...)
+
+		if len(issues) >= max {
+			return issues[:max], result.GetTotal() > max, nil
+		}
+		if resp.NextPage == 0 {
+			return issues, result.GetIncompleteResults() || len(issues) < result.GetTotal(), nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// pageSize picks the largest page GitHub allows without overshooting max by much
+func pageSize(max int) int {
+	if max > 0 && max < 100 {
+		return max
+	}
+	return 100
+}
+
+// Fetch commits for a specific PR, following pagination. GitHub caps this
+// endpoint at 250 commits per PR.
 func fetchCommits(client *github.Client, ctx context.Context, owner, repo string, prNumber int) ([]*github.RepositoryCommit, error) {
-	commits, _, err := client.PullRequests.ListCommits(ctx, owner, repo, prNumber, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error fetching commits for PR #%d: %w", prNumber, err)
+	opts := &github.ListOptions{PerPage: 100}
+
+	var commits []*github.RepositoryCommit
+	for {
+		page, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching commits for PR #%d: %w", prNumber, err)
+		}
+		commits = append(commits, page...)
+
+		if resp.NextPage == 0 {
+			return commits, nil
+		}
+		opts.Page = resp.NextPage
 	}
-	return commits, nil
 }
 
 // Parse "owner/repo" format
@@ -0,0 +1,435 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+// GitHub backends selectable with plugins.github.backend
+const (
+	backendREST    = "rest"
+	backendGraphQL = "graphql"
+)
+
+// graphQLPageSize is the number of PRs per query; each carries up to 100
+// commits inline, and PRs with more fall back to a REST commit listing
+const graphQLPageSize = 25
+
+// graphQLPullRequestFields selects everything a summary needs from a PR, so
+// a page of PRs with their commits, reviews and linked issues is one request
+const graphQLPullRequestFields = `
+	number title state url createdAt updatedAt closedAt mergedAt headRefName additions deletions
+	author { login }
+	repository { name owner { login } }
+	commits(first: 100) {
+		totalCount
+		nodes { commit { oid message url author { name email date user { login } } committer { date } } }
+	}
+	reviews(first: 50) { totalCount nodes { state author { login } } }
+	closingIssuesReferences(first: 10) { nodes { number title url } }`
+
+const graphQLRepoPullRequests = `query($owner: String!, $name: String!, $first: Int!, $after: String) {
+	repository(owner: $owner, name: $name) {
+		pullRequests(first: $first, after: $after, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			totalCount
+			pageInfo { hasNextPage endCursor }
+			nodes {` + graphQLPullRequestFields + `}
+		}
+	}
+}`
+
+const graphQLSearchPullRequests = `query($query: String!, $first: Int!, $after: String) {
+	search(query: $query, type: ISSUE, first: $first, after: $after) {
+		issueCount
+		pageInfo { hasNextPage endCursor }
+		nodes { ... on PullRequest {` + graphQLPullRequestFields + `} }
+	}
+}`
+
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type graphQLLogin struct {
+	Login string `json:"login"`
+}
+
+type graphQLPullRequest struct {
+	Number      int          `json:"number"`
+	Title       string       `json:"title"`
+	State       string       `json:"state"` // OPEN, CLOSED or MERGED
+	URL         string       `json:"url"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	UpdatedAt   time.Time    `json:"updatedAt"`
+	ClosedAt    *time.Time   `json:"closedAt"`
+	MergedAt    *time.Time   `json:"mergedAt"`
+	HeadRefName string       `json:"headRefName"`
+	Additions   int          `json:"additions"`
+	Deletions   int          `json:"deletions"`
+	Author      graphQLLogin `json:"author"`
+	Repository  struct {
+		Name  string       `json:"name"`
+		Owner graphQLLogin `json:"owner"`
+	} `json:"repository"`
+	Commits struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Commit struct {
+				OID     string `json:"oid"`
+				Message string `json:"message"`
+				URL     string `json:"url"`
+				Author  struct {
+					Name  string       `json:"name"`
+					Email string       `json:"email"`
+					Date  time.Time    `json:"date"`
+					User  graphQLLogin `json:"user"`
+				} `json:"author"`
+				Committer struct {
+					Date time.Time `json:"date"`
+				} `json:"committer"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+	Reviews struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			State  string       `json:"state"`
+			Author graphQLLogin `json:"author"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	ClosingIssuesReferences struct {
+		Nodes []struct {
+			Number int    `json:"number"`
+			Title  string `json:"title"`
+			URL    string `json:"url"`
+		} `json:"nodes"`
+	} `json:"closingIssuesReferences"`
+}
+
+type graphQLPullRequestPage struct {
+	PageInfo graphQLPageInfo      `json:"pageInfo"`
+	Nodes    []graphQLPullRequest `json:"nodes"`
+}
+
+// graphQLClient posts queries to the GraphQL API of the host a REST client points at
+type graphQLClient struct {
+	http     *http.Client
+	endpoint string
+}
+
+func newGraphQLClient(client *github.Client) *graphQLClient {
+	return &graphQLClient{http: client.Client(), endpoint: graphQLEndpoint(client.BaseURL)}
+}
+
+// graphQLEndpoint derives the GraphQL URL from a REST base URL:
+// https://api.github.com/ → /graphql, https://ghes/api/v3/ → /api/graphql
+func graphQLEndpoint(base *url.URL) string {
+	u := *base
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/graphql"
+	}
+	return u.String()
+}
+
+// query runs a GraphQL query and decodes its data into out
+func (c *graphQLClient) query(ctx context.Context, query string, variables map[string]any, out any) error {
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return fmt.Errorf("failed to encode GraphQL query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create GraphQL request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return fmt.Errorf("GraphQL request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read GraphQL response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GraphQL request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return fmt.Errorf("failed to decode GraphQL response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		messages := make([]string, len(result.Errors))
+		for i, e := range result.Errors {
+			messages[i] = e.Message
+		}
+		return fmt.Errorf("GraphQL query failed: %s", strings.Join(messages, "; "))
+	}
+	return json.Unmarshal(result.Data, out)
+}
+
+// collectContributionsGraphQL is the GraphQL counterpart of
+// collectContributionsREST: PRs come a page at a time with their commits,
+// reviews, line counts and closing issues inline, instead of one commit
+// request per PR. Only PRs with more commits than fit inline cost an extra
+// REST call. On cancellation the contributions so far are returned with ctx.Err().
+func (g *GitHubPlugin) collectContributionsGraphQL(client *github.Client, ctx context.Context, search PRSearch, emails []string) ([]contrib.Contribution, error) {
+	gql := newGraphQLClient(client)
+	window := Query{Since: search.Since, Until: search.Until}
+
+	var contributions []contrib.Contribution
+	collect := func(prs []graphQLPullRequest) error {
+		for _, node := range prs {
+			if !search.MergedOnly && !window.InRange(node.UpdatedAt) {
+				continue
+			}
+			prContributions, err := graphQLContributions(client, ctx, node, emails)
+			contributions = append(contributions, prContributions...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if search.usesSearch() {
+		err := g.graphQLSearchPRs(gql, ctx, search, collect)
+		return contributions, err
+	}
+
+	for _, target := range search.Repos {
+		owner, repo, err := parseOwnerRepo(target)
+		if err != nil {
+			return contributions, err
+		}
+		if err := g.graphQLRepoPRs(gql, ctx, owner, repo, collect); err != nil {
+			return contributions, err
+		}
+	}
+	return contributions, nil
+}
+
+// graphQLRepoPRs pages through a repo's PRs, most recently updated first,
+// stopping at the PR cap. Date-ranged searches never get here: usesSearch
+// sends them to graphQLSearchPRs, which lets the search API apply the range.
+func (g *GitHubPlugin) graphQLRepoPRs(gql *graphQLClient, ctx context.Context, owner, repo string, collect func([]graphQLPullRequest) error) error {
+	maxPRs := g.prLimit()
+	variables := map[string]any{"owner": owner, "name": repo, "first": min(graphQLPageSize, maxPRs)}
+
+	seen := 0
+	for {
+		var data struct {
+			Repository *struct {
+				PullRequests struct {
+					TotalCount int `json:"totalCount"`
+					graphQLPullRequestPage
+				} `json:"pullRequests"`
+			} `json:"repository"`
+		}
+		if err := gql.query(ctx, graphQLRepoPullRequests, variables, &data); err != nil {
+			return err
+		}
+		if data.Repository == nil {
+			return fmt.Errorf("repository %s/%s not found", owner, repo)
+		}
+
+		page := data.Repository.PullRequests
+		nodes := page.Nodes
+		if seen+len(nodes) > maxPRs {
+			nodes = nodes[:maxPRs-seen]
+		}
+		seen += len(nodes)
+		if err := collect(nodes); err != nil {
+			return err
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return nil
+		}
+		if seen >= maxPRs {
+			g.warnTruncated(owner+"/"+repo, maxPRs)
+			return nil
+		}
+		variables["after"] = page.PageInfo.EndCursor
+	}
+}
+
+// graphQLSearchPRs pages through the search API results for search, up to the PR cap
+func (g *GitHubPlugin) graphQLSearchPRs(gql *graphQLClient, ctx context.Context, search PRSearch, collect func([]graphQLPullRequest) error) error {
+	maxPRs := g.prLimit()
+	variables := map[string]any{"query": search.query(), "first": min(graphQLPageSize, maxPRs)}
+
+	seen := 0
+	for {
+		var data struct {
+			Search struct {
+				IssueCount int `json:"issueCount"`
+				graphQLPullRequestPage
+			} `json:"search"`
+		}
+		if err := gql.query(ctx, graphQLSearchPullRequests, variables, &data); err != nil {
+			return err
+		}
+
+		nodes := data.Search.Nodes
+		if seen+len(nodes) > maxPRs {
+			nodes = nodes[:maxPRs-seen]
+		}
+		seen += len(nodes)
+		if err := collect(nodes); err != nil {
+			return err
+		}
+
+		if !data.Search.PageInfo.HasNextPage {
+			return nil
+		}
+		if seen >= maxPRs {
+			g.warnTruncated(search.describe(), maxPRs)
+			return nil
+		}
+		variables["after"] = data.Search.PageInfo.EndCursor
+	}
+}
+
+// graphQLContributions turns a GraphQL PR into the same PR and commit records
+// the REST path produces, plus line counts, reviewers and closing issues. A
+// failure to list commits that didn't fit inline is returned rather than
+// skipping the PR, so a sync doesn't advance its cursor past it.
+func graphQLContributions(client *github.Client, ctx context.Context, node graphQLPullRequest, emails []string) ([]contrib.Contribution, error) {
+	owner, repo := node.Repository.Owner.Login, node.Repository.Name
+	pr := node.restPullRequest()
+
+	commits := node.restCommits()
+	if node.Commits.TotalCount > len(commits) {
+		var err error
+		if commits, err = fetchCommits(client, ctx, owner, repo, node.Number); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			logger.Logger.Warn().Err(err).Str("repo", owner+"/"+repo).Int("pr", node.Number).Msg("Failed to fetch PR commits")
+			return nil, fmt.Errorf("failed to fetch commits for %s/%s#%d: %w", owner, repo, node.Number, err)
+		}
+	}
+	if len(emails) > 0 {
+		commits = filterCommitsByEmail(commits, emails...)
+		if len(commits) == 0 {
+			return nil, nil
+		}
+	}
+
+	prContribution := pullRequestContribution(owner, repo, pr)
+	prContribution.Metadata["additions"] = node.Additions
+	prContribution.Metadata["deletions"] = node.Deletions
+
+	var reviewers []string
+	for _, review := range node.Reviews.Nodes {
+		reviewers = append(reviewers, review.Author.Login+":"+strings.ToLower(review.State))
+	}
+	prContribution.Metadata["review_count"] = node.Reviews.TotalCount
+	if len(reviewers) > 0 {
+		prContribution.Metadata["reviews"] = reviewers
+	}
+
+	for _, issue := range node.ClosingIssuesReferences.Nodes {
+		prContribution.Links = append(prContribution.Links, contrib.Link{
+			Rel:    "issue",
+			Target: strconv.Itoa(issue.Number),
+			URL:    issue.URL,
+		})
+	}
+
+	contributions := []contrib.Contribution{prContribution}
+	for _, commit := range commits {
+		contributions = append(contributions, commitContribution(owner, repo, commit, prContribution))
+	}
+	return contributions, nil
+}
+
+// restPullRequest converts the node to the REST type so both backends share contribution builders
+func (n graphQLPullRequest) restPullRequest() *github.PullRequest {
+	state := "open"
+	if n.State != "OPEN" {
+		state = "closed"
+	}
+
+	pr := &github.PullRequest{
+		Number:    github.Int(n.Number),
+		Title:     github.String(n.Title),
+		State:     github.String(state),
+		HTMLURL:   github.String(n.URL),
+		CreatedAt: &github.Timestamp{Time: n.CreatedAt},
+		UpdatedAt: &github.Timestamp{Time: n.UpdatedAt},
+		Head:      &github.PullRequestBranch{Ref: github.String(n.HeadRefName)},
+		Additions: github.Int(n.Additions),
+		Deletions: github.Int(n.Deletions),
+	}
+	if n.Author.Login != "" {
+		pr.User = &github.User{Login: github.String(n.Author.Login)}
+	}
+	if n.ClosedAt != nil {
+		pr.ClosedAt = &github.Timestamp{Time: *n.ClosedAt}
+	}
+	if n.MergedAt != nil {
+		pr.MergedAt = &github.Timestamp{Time: *n.MergedAt}
+	}
+	return pr
+}
+
+// restCommits converts the inline commits to the REST type
+func (n graphQLPullRequest) restCommits() []*github.RepositoryCommit {
+	commits := make([]*github.RepositoryCommit, 0, len(n.Commits.Nodes))
+	for _, node := range n.Commits.Nodes {
+		c := node.Commit
+		commit := &github.RepositoryCommit{
+			SHA:     github.String(c.OID),
+			HTMLURL: github.String(c.URL),
+			Commit: &github.Commit{
+				Message: github.String(c.Message),
+				Author: &github.CommitAuthor{
+					Name:  github.String(c.Author.Name),
+					Email: github.String(c.Author.Email),
+					Date:  &github.Timestamp{Time: c.Author.Date},
+				},
+				Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: c.Committer.Date}},
+			},
+		}
+		if c.Author.User.Login != "" {
+			commit.Author = &github.User{Login: github.String(c.Author.User.Login)}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// useGraphQLFallback logs why the GraphQL backend failed before REST takes over
+func useGraphQLFallback(err error) {
+	logger.Logger.Warn().Err(err).Msg("GraphQL fetch failed; falling back to the REST API")
+}
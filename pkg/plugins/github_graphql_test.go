@@ -0,0 +1,313 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+// fakeGitHub serves canned GraphQL pages and REST responses and records the GraphQL requests it gets
+type fakeGitHub struct {
+	t       *testing.T
+	mu      sync.Mutex
+	queries []graphQLRequest
+	graphQL func(req graphQLRequest) string // Returns the response body
+	rest    map[string]string               // Path → JSON body
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func (f *fakeGitHub) client() *github.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			var req graphQLRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				f.t.Errorf("bad GraphQL request: %v", err)
+			}
+			f.mu.Lock()
+			f.queries = append(f.queries, req)
+			f.mu.Unlock()
+			io.WriteString(w, f.graphQL(req))
+			return
+		}
+		body, ok := f.rest[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, body)
+	}))
+	f.t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client
+}
+
+// graphQLPR renders a pullRequest node. commits are "sha:email" pairs; totalCount may exceed them.
+func graphQLPR(number int, updated time.Time, state string, totalCommits int, commits ...string) map[string]any {
+	nodes := make([]map[string]any, len(commits))
+	for i, c := range commits {
+		sha, email, _ := strings.Cut(c, ":")
+		nodes[i] = map[string]any{"commit": map[string]any{
+			"oid": sha, "message": "Commit " + sha + "\n\nDetails", "url": "https://github.com/acme/api/commit/" + sha,
+			"author":    map[string]any{"name": "Me", "email": email, "date": updated.Add(-time.Hour), "user": map[string]any{"login": "me"}},
+			"committer": map[string]any{"date": updated.Add(-time.Hour)},
+		}}
+	}
+	pr := map[string]any{
+		"number": number, "title": fmt.Sprintf("PR %d", number), "state": state,
+		"url":       fmt.Sprintf("https://github.com/acme/api/pull/%d", number),
+		"createdAt": updated.Add(-48 * time.Hour), "updatedAt": updated,
+		"headRefName": fmt.Sprintf("feature/CS-%d", number), "additions": 10 * number, "deletions": number,
+		"author":                  map[string]any{"login": "me"},
+		"repository":              map[string]any{"name": "api", "owner": map[string]any{"login": "acme"}},
+		"commits":                 map[string]any{"totalCount": totalCommits, "nodes": nodes},
+		"reviews":                 map[string]any{"totalCount": 0, "nodes": []any{}},
+		"closingIssuesReferences": map[string]any{"nodes": []any{}},
+	}
+	if state == "MERGED" {
+		pr["closedAt"], pr["mergedAt"] = updated, updated
+	}
+	return pr
+}
+
+func graphQLPage(root string, hasNext bool, cursor string, nodes ...map[string]any) string {
+	page := map[string]any{
+		"totalCount": len(nodes), "issueCount": len(nodes),
+		"pageInfo": map[string]any{"hasNextPage": hasNext, "endCursor": cursor},
+		"nodes":    nodes,
+	}
+	var data map[string]any
+	if root == "search" {
+		data = map[string]any{"search": page}
+	} else {
+		data = map[string]any{"repository": map[string]any{"pullRequests": page}}
+	}
+	body, _ := json.Marshal(map[string]any{"data": data})
+	return string(body)
+}
+
+func TestCollectContributionsGraphQLRepoPages(t *testing.T) {
+	updated := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	merged := graphQLPR(2, updated, "MERGED", 1, "bbb2222:me@example.com")
+	merged["reviews"] = map[string]any{"totalCount": 3, "nodes": []any{
+		map[string]any{"state": "APPROVED", "author": map[string]any{"login": "alice"}},
+		map[string]any{"state": "CHANGES_REQUESTED", "author": map[string]any{"login": "bob"}},
+	}}
+	merged["closingIssuesReferences"] = map[string]any{"nodes": []any{
+		map[string]any{"number": 7, "title": "Crash on save", "url": "https://github.com/acme/api/issues/7"},
+	}}
+	// PR 1 has more commits than fit inline, so they are listed over REST
+	open := graphQLPR(1, updated.Add(-24*time.Hour), "OPEN", 101, "aaa1111:me@example.com")
+
+	fake := &fakeGitHub{
+		t: t,
+		graphQL: func(req graphQLRequest) string {
+			if req.Variables["after"] == nil {
+				return graphQLPage("repository", true, "cursor-1", merged)
+			}
+			return graphQLPage("repository", false, "", open)
+		},
+		rest: map[string]string{
+			"/repos/acme/api/pulls/1/commits": `[
+				{"sha":"aaa1111","commit":{"message":"First","author":{"email":"me@example.com","date":"2026-07-30T10:00:00Z"}}},
+				{"sha":"ccc3333","commit":{"message":"Second","author":{"email":"someone@example.com","date":"2026-07-30T11:00:00Z"}}}
+			]`,
+		},
+	}
+	g := &GitHubPlugin{backend: backendGraphQL}
+
+	contributions, err := g.collectContributions(fake.client(), context.Background(), PRSearch{Repos: []string{"acme/api"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fake.queries) != 2 {
+		t.Fatalf("made %d GraphQL requests, want 2 pages", len(fake.queries))
+	}
+	if got := fake.queries[1].Variables["after"]; got != "cursor-1" {
+		t.Errorf("second page after = %v, want cursor-1", got)
+	}
+	if got := fake.queries[0].Variables["owner"]; got != "acme" || !strings.Contains(fake.queries[0].Query, "pullRequests(") {
+		t.Errorf("first request = %+v, want the acme/api pullRequests query", fake.queries[0])
+	}
+
+	var ids []string
+	for _, c := range contributions {
+		ids = append(ids, string(c.Kind)+":"+c.ID)
+	}
+	want := []string{"pull_request:2", "commit:bbb2222", "pull_request:1", "commit:aaa1111", "commit:ccc3333"}
+	if !slices.Equal(ids, want) {
+		t.Fatalf("contributions = %v, want %v", ids, want)
+	}
+
+	pr := contributions[0]
+	if pr.Project != "acme/api" || pr.Status != "closed" || pr.Title != "PR 2" || pr.URL != "https://github.com/acme/api/pull/2" {
+		t.Errorf("PR = %+v", pr)
+	}
+	if len(pr.Authors) != 1 || pr.Authors[0].Login != "me" {
+		t.Errorf("PR authors = %+v", pr.Authors)
+	}
+	for key, want := range map[string]any{"merged": true, "branch": "feature/CS-2", "additions": 20, "deletions": 2, "review_count": 3} {
+		if got := pr.Metadata[key]; got != want {
+			t.Errorf("PR metadata %s = %v, want %v", key, got, want)
+		}
+	}
+	if got := pr.MetadataStrings("reviews"); !slices.Equal(got, []string{"alice:approved", "bob:changes_requested"}) {
+		t.Errorf("PR reviews = %v", got)
+	}
+	if len(pr.Links) != 1 || pr.Links[0] != (contrib.Link{Rel: "issue", Target: "7", URL: "https://github.com/acme/api/issues/7"}) {
+		t.Errorf("PR links = %+v, want closing issue 7", pr.Links)
+	}
+
+	commit := contributions[1]
+	if commit.Title != "Commit bbb2222" || commit.Authors[0].Email != "me@example.com" || commit.Authors[0].Login != "me" {
+		t.Errorf("commit = %+v", commit)
+	}
+	if len(commit.Links) != 1 || commit.Links[0].Rel != "pull_request" || commit.Links[0].Target != "2" {
+		t.Errorf("commit links = %+v, want PR 2", commit.Links)
+	}
+	if open := contributions[2]; open.Status != "open" || open.Metadata["merged"] != false {
+		t.Errorf("open PR = %+v", open)
+	}
+}
+
+func TestCollectContributionsGraphQLSearchCap(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	updated := since.AddDate(0, 1, 0)
+
+	page := 0
+	fake := &fakeGitHub{
+		t: t,
+		graphQL: func(req graphQLRequest) string {
+			page++
+			return graphQLPage("search", true, fmt.Sprintf("cursor-%d", page),
+				graphQLPR(2*page, updated, "OPEN", 1, "a:me@example.com"),
+				graphQLPR(2*page+1, updated, "OPEN", 1, "b:someone@example.com"))
+		},
+	}
+	g := &GitHubPlugin{backend: backendGraphQL, maxPRs: 3}
+	search := PRSearch{Repos: []string{"acme/api"}, Author: "me", Since: since}
+
+	contributions, err := g.collectContributions(fake.client(), context.Background(), search, []string{"me@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fake.queries) != 2 {
+		t.Fatalf("made %d GraphQL requests, want 2 pages before the cap", len(fake.queries))
+	}
+	query, _ := fake.queries[0].Variables["query"].(string)
+	if !strings.Contains(fake.queries[0].Query, "search(") || query != search.query() {
+		t.Errorf("search query = %q, want %q", query, search.query())
+	}
+	if got := fake.queries[0].Variables["first"]; got != float64(3) {
+		t.Errorf("first = %v, want the PR cap of 3", got)
+	}
+	if !g.truncated {
+		t.Error("hitting max_prs was not recorded")
+	}
+
+	// Three PRs fit under the cap; the one whose only commit is someone else's is dropped by the email filter
+	var prs []string
+	for _, c := range contributions {
+		if c.Kind == contrib.KindPullRequest {
+			prs = append(prs, c.ID)
+		}
+	}
+	if !slices.Equal(prs, []string{"2", "4"}) {
+		t.Errorf("PRs = %v, want 2 and 4", prs)
+	}
+}
+
+func TestCollectContributionsGraphQLFallsBackToREST(t *testing.T) {
+	fake := &fakeGitHub{
+		t: t,
+		graphQL: func(req graphQLRequest) string {
+			return `{"errors":[{"type":"FORBIDDEN","message":"Resource not accessible by integration"}]}`
+		},
+		rest: map[string]string{
+			"/repos/acme/api/pulls": `[{"number":5,"title":"REST PR","state":"open","updated_at":"2026-08-01T12:00:00Z",
+				"head":{"ref":"fix-5"},"base":{"repo":{"name":"api","owner":{"login":"acme"}}}}]`,
+			"/repos/acme/api/pulls/5/commits": `[{"sha":"ddd4444","commit":{"message":"Fix","author":{"email":"me@example.com"}}}]`,
+		},
+	}
+	g := &GitHubPlugin{backend: backendGraphQL}
+
+	contributions, err := g.collectContributions(fake.client(), context.Background(), PRSearch{Repos: []string{"acme/api"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.queries) != 1 {
+		t.Errorf("made %d GraphQL requests, want 1 before falling back", len(fake.queries))
+	}
+	if len(contributions) != 2 || contributions[0].ID != "5" || contributions[0].Metadata["branch"] != "fix-5" || contributions[1].ID != "ddd4444" {
+		t.Errorf("contributions = %+v, want REST PR 5 and its commit", contributions)
+	}
+}
+
+func TestGraphQLContributionsCommitsFailure(t *testing.T) {
+	// The PR has more commits than fit inline and listing them over REST fails
+	fake := &fakeGitHub{t: t}
+	node := graphQLPR(1, time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC), "OPEN", 101, "aaa1111:me@example.com")
+	data, _ := json.Marshal(node)
+	var pr graphQLPullRequest
+	if err := json.Unmarshal(data, &pr); err != nil {
+		t.Fatal(err)
+	}
+
+	contributions, err := graphQLContributions(fake.client(), context.Background(), pr, nil)
+	if err == nil || !strings.Contains(err.Error(), "acme/api#1") {
+		t.Errorf("err = %v, want the failed commit listing of acme/api#1", err)
+	}
+	if len(contributions) != 0 {
+		t.Errorf("contributions = %+v, want none without the PR's commits", contributions)
+	}
+}
+
+func TestCollectContributionsGraphQLCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fake := &fakeGitHub{
+		t: t,
+		graphQL: func(req graphQLRequest) string {
+			cancel()
+			return graphQLPage("repository", true, "cursor-1", graphQLPR(1, time.Now(), "OPEN", 1, "a:me@example.com"))
+		},
+	}
+	g := &GitHubPlugin{backend: backendGraphQL}
+
+	_, err := g.collectContributions(fake.client(), ctx, PRSearch{Repos: []string{"acme/api"}}, nil)
+	if !IsCancellation(err) {
+		t.Fatalf("err = %v, want a cancellation and no REST fallback", err)
+	}
+}
+
+func TestGraphQLEndpoint(t *testing.T) {
+	for base, want := range map[string]string{
+		"https://api.github.com/":      "https://api.github.com/graphql",
+		"https://ghe.acme.dev/api/v3/": "https://ghe.acme.dev/api/graphql",
+		"http://127.0.0.1:8080/":       "http://127.0.0.1:8080/graphql",
+	} {
+		u, _ := url.Parse(base)
+		if got := graphQLEndpoint(u); got != want {
+			t.Errorf("graphQLEndpoint(%s) = %s, want %s", base, got, want)
+		}
+	}
+}
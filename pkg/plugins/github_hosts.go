@@ -0,0 +1,145 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"golang.org/x/oauth2"
+)
+
+const defaultTokenEnv = "GITHUB_TOKEN"
+
+// githubHost is one GitHub instance csync talks to: github.com (or the
+// Enterprise Server in plugins.github.base_url) plus any plugins.github.hosts.
+type githubHost struct {
+	name      string // Empty for the default instance
+	baseURL   string // Empty for api.github.com
+	uploadURL string
+	tokenEnv  string
+	login     string // Overrides the identity login on this host
+	repos     []string
+}
+
+// loadGitHubHosts returns the default instance followed by the configured extra hosts
+func loadGitHubHosts(cfg *config.Config) []githubHost {
+	gh := cfg.Plugins.GitHub
+	hosts := []githubHost{{
+		baseURL:   gh.BaseURL,
+		uploadURL: gh.UploadURL,
+		tokenEnv:  defaultTokenEnv,
+		repos:     gh.Repos,
+	}}
+
+	for _, h := range gh.Hosts {
+		tokenEnv := h.TokenEnv
+		if tokenEnv == "" {
+			tokenEnv = defaultTokenEnv
+		}
+		hosts = append(hosts, githubHost{
+			name:      h.Name,
+			baseURL:   h.BaseURL,
+			uploadURL: h.UploadURL,
+			tokenEnv:  tokenEnv,
+			login:     h.Login,
+			repos:     h.Repos,
+		})
+	}
+	return hosts
+}
+
+// host looks up a configured host by name; "" is the default instance
+func (g *GitHubPlugin) host(name string) (githubHost, error) {
+	for _, h := range g.hostList() {
+		if h.name == name {
+			return h, nil
+		}
+	}
+	return githubHost{}, fmt.Errorf("unknown GitHub host %q; add it under plugins.github.hosts", name)
+}
+
+// hostList returns the configured hosts, falling back to plain github.com before Init has run
+func (g *GitHubPlugin) hostList() []githubHost {
+	if len(g.hosts) == 0 {
+		return []githubHost{{tokenEnv: defaultTokenEnv}}
+	}
+	return g.hosts
+}
+
+// splitTarget separates the host from a target such as "ghes:owner/repo".
+// Targets without a host prefix belong to the default instance.
+func (g *GitHubPlugin) splitTarget(target string) (githubHost, string, error) {
+	name, rest, found := strings.Cut(target, ":")
+	if !found {
+		name, rest = "", target
+	}
+	host, err := g.host(name)
+	return host, rest, err
+}
+
+// label names the host for logs and headings
+func (h githubHost) label() string {
+	if h.baseURL == "" {
+		return "github.com"
+	}
+	if u, err := url.Parse(h.baseURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return h.baseURL
+}
+
+// qualify prefixes the project of contributions from an extra host with its
+// name, so repos with the same owner/repo on two hosts stay apart in the store
+// and reports. It passes err through so it can wrap a collect call directly.
+func (h githubHost) qualify(contributions []contrib.Contribution, err error) ([]contrib.Contribution, error) {
+	if h.name == "" {
+		return contributions, err
+	}
+	for i := range contributions {
+		c := &contributions[i]
+		c.Project = h.name + ":" + c.Project
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]any)
+		}
+		c.Metadata["host"] = h.label()
+	}
+	return contributions, err
+}
+
+// newGitHubClient returns a client for the named host, authenticated with the
+// token in its environment variable and backing off on rate limits
+func (g *GitHubPlugin) newGitHubClient(ctx context.Context, hostName string) (*github.Client, error) {
+	host, err := g.host(hostName)
+	if err != nil {
+		return nil, err
+	}
+
+	token := os.Getenv(host.tokenEnv)
+	if token == "" {
+		return nil, errors.New("❌ " + host.tokenEnv + " is not set")
+	}
+
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = &rateLimitTransport{base: tc.Transport}
+	client := github.NewClient(tc)
+	if host.baseURL == "" {
+		return client, nil
+	}
+
+	uploadURL := host.uploadURL
+	if uploadURL == "" {
+		uploadURL = host.baseURL
+	}
+	client, err = client.WithEnterpriseURLs(host.baseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL for %s: %w", host.label(), err)
+	}
+	return client, nil
+}
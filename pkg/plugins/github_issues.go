@@ -0,0 +1,325 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+const githubIssuesUsage = "Usage: csync plugin exec github issues [owner/repo] [--user LOGIN|@me] [--org ORG]... [--host NAME] " +
+	"[--since DATE] [--until DATE] [--max-prs N]"
+
+// Issue roles recorded in a contribution's "roles" metadata
+const (
+	issueRoleAuthored = "authored"
+	issueRoleAssigned = "assigned"
+	issueRoleClosed   = "closed"
+)
+
+func (g *GitHubPlugin) executeIssues(ctx context.Context, args []string) error {
+	var search PRSearch
+	var user string
+	fs := newFlagSet("github issues")
+	dates := addRangeFlags(fs)
+	fs.StringVar(&user, "user", "@me", "Whose issues to collect")
+	fs.StringSliceVar(&search.Orgs, "org", nil, "Limit to these orgs (repeatable)")
+	fs.StringVar(&search.Host, "host", "", "Query this plugins.github.hosts entry instead of the default instance")
+	fs.IntVar(&g.maxPRs, "max-prs", g.maxPRs, "Stop after this many issues per search (default plugins.github.max_prs)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, githubIssuesUsage)
+	}
+	r, err := dates.parse()
+	if err != nil {
+		return err
+	}
+	search.Since, search.Until = r.Since, r.Until
+
+	if fs.NArg() >= 1 {
+		if _, _, err := parseOwnerRepo(fs.Arg(0)); err != nil {
+			return err
+		}
+		search.Repos = []string{fs.Arg(0)}
+	}
+
+	host, err := g.host(search.Host)
+	if err != nil {
+		return err
+	}
+	client, err := g.newGitHubClient(ctx, search.Host)
+	if err != nil {
+		return err
+	}
+
+	login, err := resolveLogin(client, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	logger.Logger.Info().Str("user", login).Str("scope", search.describe()).Msg("📌 Issue Activity")
+	err = printSection(func() ([]contrib.Contribution, error) {
+		return host.qualify(g.collectIssues(client, ctx, search, login))
+	}, func(issues []contrib.Contribution) { printGitHubIssues(login, issues) })
+
+	if IsCancellation(err) {
+		fmt.Printf("\n⚠️ Stopped early (%v); showing partial results.\n", err)
+	}
+	return err
+}
+
+// collectIssues returns the GitHub issues login authored, was assigned or
+// closed within the search's repos/orgs and date range. Each issue records the
+// roles that apply, its labels and the PRs that reference it. GitHub search has
+// no closed-by qualifier, so closing is detected on closed issues involving
+// login by checking who closed them in the issue timeline.
+// On cancellation the issues gathered so far are returned alongside ctx.Err().
+func (g *GitHubPlugin) collectIssues(client *github.Client, ctx context.Context, search PRSearch, login string) ([]contrib.Contribution, error) {
+	maxItems := g.prLimit()
+	scope := strings.Join(search.scopeTerms(), " ")
+
+	searches := []struct {
+		role  string
+		query string
+	}{
+		{issueRoleAuthored, fmt.Sprintf("is:issue %s author:%s %s", scope, login, dateQualifier("created", search.Since, search.Until))},
+		// Only the start bounds this search: an issue worked on within the range may have been updated
+		// after it ends. timelineRoles keeps it only if it was assigned or closed within the range.
+		{issueRoleAssigned, fmt.Sprintf("is:issue %s assignee:%s %s", scope, login, dateQualifier("updated", search.Since, time.Time{}))},
+		{issueRoleClosed, fmt.Sprintf("is:issue is:closed %s involves:%s %s", scope, login, dateQualifier("closed", search.Since, search.Until))},
+	}
+
+	var order []string
+	found := make(map[string]*github.Issue)
+	roles := make(map[string][]string)
+	for _, s := range searches {
+		issues, truncated, err := searchIssues(client, ctx, strings.Join(strings.Fields(s.query), " "), maxItems)
+		if err != nil {
+			return nil, err
+		}
+		if truncated {
+			g.warnTruncated(fmt.Sprintf("issues %s by %s", s.role, login), maxItems)
+		}
+
+		for _, issue := range issues {
+			key := issue.GetHTMLURL()
+			if _, seen := found[key]; !seen {
+				found[key] = issue
+				order = append(order, key)
+			}
+			if s.role != issueRoleClosed {
+				roles[key] = append(roles[key], s.role)
+			}
+		}
+	}
+
+	window := Query{Since: search.Since, Until: search.Until}
+
+	var contributions []contrib.Contribution
+	for _, key := range order {
+		if err := ctx.Err(); err != nil {
+			return contributions, err
+		}
+
+		issue := found[key]
+		owner, repo, err := issueRepo(issue)
+		if err != nil {
+			return contributions, err
+		}
+
+		timeline, err := fetchTimeline(client, ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			return contributions, err
+		}
+
+		issueRoles, linkedPRs := timelineRoles(roles[key], timeline, login, window)
+		if len(issueRoles) == 0 {
+			continue // Someone else closed it, or it was assigned but not worked on within the range
+		}
+		contributions = append(contributions, githubIssueContribution(owner, repo, issue, login, issueRoles, linkedPRs))
+	}
+	return contributions, nil
+}
+
+// timelineRoles checks the roles an issue was found with against its timeline
+// and returns the PRs that reference it. Closing is credited when login closed
+// the issue within window. When window is bounded, being assigned only counts
+// if login was assigned, or the issue was closed, within it.
+func timelineRoles(found []string, timeline []*github.Timeline, login string, window Query) ([]string, []contrib.Link) {
+	bounded := !window.Since.IsZero() || !window.Until.IsZero()
+	var assignedInRange, closedBy bool
+	var linkedPRs []contrib.Link
+	for _, event := range timeline {
+		at := event.GetCreatedAt().Time
+		switch event.GetEvent() {
+		case "assigned":
+			if strings.EqualFold(event.GetAssignee().GetLogin(), login) && window.InRange(at) {
+				assignedInRange = true
+			}
+		case "closed":
+			if window.InRange(at) {
+				assignedInRange = true
+				closedBy = closedBy || strings.EqualFold(event.GetActor().GetLogin(), login)
+			}
+		case "cross-referenced":
+			if source := event.GetSource().GetIssue(); source != nil && source.IsPullRequest() {
+				if ref, ok := prReference(source); ok {
+					linkedPRs = append(linkedPRs, contrib.Link{Rel: "pull_request", Target: ref, URL: source.GetHTMLURL()})
+				}
+			}
+		}
+	}
+
+	var roles []string
+	for _, role := range found {
+		if role == issueRoleAssigned && bounded && !assignedInRange {
+			continue
+		}
+		roles = append(roles, role)
+	}
+	if closedBy && !slices.Contains(roles, issueRoleClosed) {
+		roles = append(roles, issueRoleClosed)
+	}
+	return roles, linkedPRs
+}
+
+// prReference names a PR as owner/repo#number, so a PR in another repo that
+// references an issue is not mistaken for one in the issue's own repo. It
+// reports false when the PR's repo can't be told from either of its URLs.
+func prReference(pr *github.Issue) (string, bool) {
+	owner, repo, err := issueRepo(pr)
+	if err != nil {
+		// Fall back to the web URL, https://github.com/owner/repo/pull/12
+		u, err := url.Parse(pr.GetHTMLURL())
+		if err != nil {
+			return "", false
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 2 || parts[0] == "" {
+			return "", false
+		}
+		owner, repo = parts[0], parts[1]
+	}
+	return owner + "/" + repo + "#" + strconv.Itoa(pr.GetNumber()), true
+}
+
+// shortPRReference drops the repo from a linked PR in the issue's own repo,
+// leaving #number; PRs in other repos keep their owner/repo#number form.
+// project may carry a host prefix, as set by githubHost.qualify.
+func shortPRReference(project, target string) string {
+	if _, repo, found := strings.Cut(project, ":"); found {
+		project = repo
+	}
+	if number, ok := strings.CutPrefix(target, project+"#"); ok {
+		return "#" + number
+	}
+	return target
+}
+
+func fetchTimeline(client *github.Client, ctx context.Context, owner, repo string, number int) ([]*github.Timeline, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var events []*github.Timeline
+	for {
+		page, resp, err := client.Issues.ListIssueTimeline(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching timeline for %s/%s#%d: %w", owner, repo, number, err)
+		}
+		events = append(events, page...)
+
+		if resp.NextPage == 0 {
+			return events, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// githubIssueContribution records issue as login's work. Besides the reporter,
+// login is listed as an author when they were assigned or closed it, so the
+// issue still matches their identity when read back from the store.
+func githubIssueContribution(owner, repo string, issue *github.Issue, login string, roles []string, linkedPRs []contrib.Link) contrib.Contribution {
+	labels := make([]string, 0, len(issue.Labels))
+	for _, label := range issue.Labels {
+		labels = append(labels, label.GetName())
+	}
+
+	var authors []contrib.Identity
+	if issue.User != nil {
+		authors = append(authors, contrib.Identity{Login: issue.User.GetLogin()})
+	}
+	if (slices.Contains(roles, issueRoleAssigned) || slices.Contains(roles, issueRoleClosed)) &&
+		!strings.EqualFold(issue.User.GetLogin(), login) {
+		authors = append(authors, contrib.Identity{Login: login})
+	}
+
+	var assignees []string
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.GetLogin())
+	}
+
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindIssue,
+		ID:        strconv.Itoa(issue.GetNumber()),
+		Project:   owner + "/" + repo,
+		Title:     issue.GetTitle(),
+		URL:       issue.GetHTMLURL(),
+		Authors:   authors,
+		Status:    issue.GetState(),
+		CreatedAt: issue.GetCreatedAt().Time,
+		UpdatedAt: issue.GetUpdatedAt().Time,
+		ClosedAt:  issue.GetClosedAt().Time,
+		Links:     linkedPRs,
+		Metadata: map[string]any{
+			"roles":     roles,
+			"labels":    labels,
+			"assignees": assignees,
+		},
+	}
+}
+
+func printGitHubIssues(login string, issues []contrib.Contribution) {
+	fmt.Printf("\n📌 GitHub issues for **%s**\n", login)
+	if len(issues) == 0 {
+		fmt.Println("\n❌ No issues found.")
+		return
+	}
+
+	byRepo := make(map[string][]contrib.Contribution)
+	for _, c := range issues {
+		byRepo[c.Project] = append(byRepo[c.Project], c)
+	}
+
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	for _, repo := range repos {
+		fmt.Printf("\n📁 %s (%d)\n", repo, len(byRepo[repo]))
+		for _, c := range byRepo[repo] {
+			roles, _ := c.Metadata["roles"].([]string)
+			fmt.Printf("   - #%s %s (%s) [%s]\n", c.ID, c.Title, c.Status, strings.Join(roles, ", "))
+
+			if labels, _ := c.Metadata["labels"].([]string); len(labels) > 0 {
+				fmt.Printf("     🏷️ %s\n", strings.Join(labels, ", "))
+			}
+			if len(c.Links) > 0 {
+				prs := make([]string, len(c.Links))
+				for i, link := range c.Links {
+					prs[i] = shortPRReference(c.Project, link.Target)
+				}
+				fmt.Printf("     🔗 Linked PRs: %s\n", strings.Join(prs, ", "))
+			}
+			fmt.Printf("     🌐 %s\n", c.URL)
+		}
+	}
+}
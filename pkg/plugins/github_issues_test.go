@@ -0,0 +1,179 @@
+package plugins
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/store"
+)
+
+func timelineEvent(event, user string, at time.Time) *github.Timeline {
+	e := &github.Timeline{Event: github.String(event), CreatedAt: &github.Timestamp{Time: at}}
+	switch event {
+	case "assigned":
+		e.Assignee = &github.User{Login: github.String(user)}
+	default:
+		e.Actor = &github.User{Login: github.String(user)}
+	}
+	return e
+}
+
+func crossReference(repositoryURL, htmlURL string, number int) *github.Timeline {
+	return &github.Timeline{
+		Event: github.String("cross-referenced"),
+		Source: &github.Source{Issue: &github.Issue{
+			Number:           github.Int(number),
+			RepositoryURL:    github.String(repositoryURL),
+			HTMLURL:          github.String(htmlURL),
+			PullRequestLinks: &github.PullRequestLinks{URL: github.String(htmlURL)},
+		}},
+	}
+}
+
+func TestTimelineRoles(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC)
+	window := Query{Since: since, Until: until}
+	inside := since.AddDate(0, 1, 0)
+	before := since.AddDate(0, -1, 0)
+	after := until.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name     string
+		found    []string
+		timeline []*github.Timeline
+		window   Query
+		want     []string
+	}{
+		{
+			name:     "assigned within the range",
+			found:    []string{issueRoleAssigned},
+			timeline: []*github.Timeline{timelineEvent("assigned", "octocat", inside)},
+			window:   window,
+			want:     []string{issueRoleAssigned},
+		},
+		{
+			name:     "assigned before, closed by someone else within",
+			found:    []string{issueRoleAssigned},
+			timeline: []*github.Timeline{timelineEvent("assigned", "octocat", before), timelineEvent("closed", "hubot", inside)},
+			window:   window,
+			want:     []string{issueRoleAssigned},
+		},
+		{
+			// Updated after the range, which the search no longer excludes, but not worked on within it
+			name:     "assigned before, only touched after",
+			found:    []string{issueRoleAssigned},
+			timeline: []*github.Timeline{timelineEvent("assigned", "octocat", before), timelineEvent("closed", "octocat", after)},
+			window:   window,
+		},
+		{
+			name:     "someone else assigned",
+			found:    []string{issueRoleAssigned},
+			timeline: []*github.Timeline{timelineEvent("assigned", "hubot", inside)},
+			window:   window,
+		},
+		{
+			name:     "unbounded keeps the assignment",
+			found:    []string{issueRoleAssigned},
+			timeline: []*github.Timeline{timelineEvent("assigned", "octocat", before)},
+			want:     []string{issueRoleAssigned},
+		},
+		{
+			name:     "authored and closed",
+			found:    []string{issueRoleAuthored},
+			timeline: []*github.Timeline{timelineEvent("closed", "OctoCat", inside)},
+			window:   window,
+			want:     []string{issueRoleAuthored, issueRoleClosed},
+		},
+		{
+			name:     "closed by someone else",
+			timeline: []*github.Timeline{timelineEvent("closed", "hubot", inside)},
+			window:   window,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _ := timelineRoles(tc.found, tc.timeline, "octocat", tc.window)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("roles = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimelineRolesLinksPRsByRepo(t *testing.T) {
+	timeline := []*github.Timeline{
+		crossReference("https://api.github.com/repos/acme/api", "https://github.com/acme/api/pull/12", 12),
+		crossReference("https://api.github.com/repos/acme/web", "https://github.com/acme/web/pull/7", 7),
+		crossReference("", "https://ghe.acme.dev/platform/deploy/pull/3", 3),
+		{Event: github.String("cross-referenced"), Source: &github.Source{Issue: &github.Issue{Number: github.Int(9)}}}, // An issue, not a PR
+	}
+
+	_, links := timelineRoles(nil, timeline, "octocat", Query{})
+	var got []string
+	for _, link := range links {
+		got = append(got, link.Target)
+	}
+	want := []string{"acme/api#12", "acme/web#7", "platform/deploy#3"}
+	if !slices.Equal(got, want) {
+		t.Errorf("linked PRs = %v, want %v", got, want)
+	}
+}
+
+func TestShortPRReference(t *testing.T) {
+	tests := []struct {
+		project, target, want string
+	}{
+		{"acme/api", "acme/api#12", "#12"},
+		{"acme/api", "acme/web#7", "acme/web#7"},
+		{"acme/api", "acme/api-v2#3", "acme/api-v2#3"},
+		{"ghes:platform/deploy", "platform/deploy#3", "#3"},
+		{"ghes:platform/deploy", "acme/api#12", "acme/api#12"},
+	}
+	for _, tc := range tests {
+		if got := shortPRReference(tc.project, tc.target); got != tc.want {
+			t.Errorf("shortPRReference(%q, %q) = %q, want %q", tc.project, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestClosedIssueMatchesCloserFromStore(t *testing.T) {
+	issue := &github.Issue{
+		Number:    github.Int(42),
+		Title:     github.String("Flaky deploy"),
+		State:     github.String("closed"),
+		User:      &github.User{Login: github.String("hubot")},
+		UpdatedAt: &github.Timestamp{Time: time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	closed := githubIssueContribution("acme", "api", issue, "octocat", []string{issueRoleClosed}, nil)
+	reported := githubIssueContribution("acme", "api", issue, "octocat", nil, nil)
+
+	dir := t.TempDir()
+	s, err := store.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reported.ID = "43"
+	s.Upsert(closed, reported)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := store.Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	me := []contrib.Identity{{Login: "octocat"}}
+	var mine []string
+	for _, c := range reopened.All() {
+		if c.AuthoredBy(me) {
+			mine = append(mine, c.ID)
+		}
+	}
+	if !slices.Equal(mine, []string{"42"}) {
+		t.Errorf("issues matching the closer offline = %v, want [42]", mine)
+	}
+}
@@ -0,0 +1,159 @@
+package plugins
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+const (
+	// maxRateLimitWait is the longest csync pauses for a rate limit reset
+	// before giving up and surfacing the error
+	maxRateLimitWait = 15 * time.Minute
+	// maxRateLimitRetries bounds how often one request is retried after being limited
+	maxRateLimitRetries = 3
+	// defaultSecondaryWait is used when a secondary limit response has no Retry-After
+	defaultSecondaryWait = time.Minute
+)
+
+// rateLimitTransport makes GitHub API calls wait out rate limits instead of
+// failing halfway through a fetch. Requests rejected by the primary limit
+// (X-RateLimit-Remaining: 0) or a secondary limit (a 429, Retry-After, or a 403
+// whose body names the secondary limit) are retried after the advertised wait,
+// or defaultSecondaryWait when none is given, and when a response shows the quota is used up
+// the transport pauses until it resets before handing the response back.
+type rateLimitTransport struct {
+	base  http.RoundTripper
+	sleep func(req *http.Request, d time.Duration) error // sleepRequest unless replaced in tests
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	sleep := t.sleep
+	if sleep == nil {
+		sleep = sleepRequest
+	}
+
+	// Retries send a clone with a fresh body: a RoundTripper must not modify the caller's request
+	send := req
+	for attempt := 0; ; attempt++ {
+		resp, err := base.RoundTrip(send)
+		if err != nil {
+			return resp, err
+		}
+
+		wait, limited := rateLimitWait(resp)
+		if limited && attempt < maxRateLimitRetries && wait <= maxRateLimitWait && (req.Body == nil || req.GetBody != nil) {
+			logger.Logger.Warn().
+				Str("url", req.URL.Path).
+				Dur("wait", wait).
+				Msg("GitHub rate limit hit; waiting before retrying")
+
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			if err := sleep(req, wait); err != nil {
+				return nil, err
+			}
+			send = req.Clone(req.Context())
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body for retry: %w", err)
+				}
+				send.Body = body
+			}
+			continue
+		}
+
+		if !limited && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if wait := untilReset(resp); wait > 0 && wait <= maxRateLimitWait {
+				logger.Logger.Warn().Dur("wait", wait).Msg("GitHub rate limit quota used up; pausing until it resets")
+				if err := sleep(req, wait); err != nil {
+					resp.Body.Close()
+					return nil, err
+				}
+			}
+		}
+		return resp, nil
+	}
+}
+
+// rateLimitWait reports whether resp was rejected by a rate limit and how long to wait before retrying
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		return defaultSecondaryWait, true
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return untilReset(resp), true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || isSecondaryLimit(resp) {
+		return defaultSecondaryWait, true
+	}
+	return 0, false
+}
+
+// isSecondaryLimit reports whether a 403 says a secondary (abuse) limit was hit.
+// Those responses may come without Retry-After while the primary quota still
+// has requests left, so they can only be told apart from a permission error by
+// the message or documentation_url in the body. The body is put back for the caller.
+func isSecondaryLimit(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+
+	text := strings.ToLower(string(body))
+	return strings.Contains(text, "secondary rate limit") || strings.Contains(text, "secondary-rate-limits") ||
+		strings.Contains(text, "abuse-rate-limits") || strings.Contains(text, "abuse detection")
+}
+
+// untilReset returns the time left until the X-RateLimit-Reset epoch, plus a second of slack
+func untilReset(resp *http.Response) time.Duration {
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return defaultSecondaryWait
+	}
+	wait := time.Until(time.Unix(reset, 0)) + time.Second
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// sleepRequest waits for d unless the request's context is cancelled first
+func sleepRequest(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,300 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// limitedResponse is one canned reply of a fake GitHub server
+type limitedResponse struct {
+	status  int
+	headers map[string]string
+	body    string
+}
+
+// rateLimitServer replies with responses in order, repeating the last one
+func rateLimitServer(t *testing.T, responses ...limitedResponse) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1)) - 1
+		resp := responses[min(n, len(responses)-1)]
+		for k, v := range resp.headers {
+			w.Header().Set(k, v)
+		}
+		w.WriteHeader(resp.status)
+		io.WriteString(w, resp.body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	ok := limitedResponse{status: http.StatusOK, headers: map[string]string{"X-RateLimit-Remaining": "4999"}, body: `{"ok":true}`}
+
+	tests := []struct {
+		name       string
+		responses  []limitedResponse
+		wantStatus int
+		wantCalls  int32
+		wantWaits  []time.Duration // Approximate for reset-based waits
+		wantBody   string
+	}{
+		{
+			name: "429 with Retry-After",
+			responses: []limitedResponse{
+				{status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "2"}},
+				ok,
+			},
+			wantStatus: http.StatusOK, wantCalls: 2, wantWaits: []time.Duration{2 * time.Second},
+		},
+		{
+			name: "429 without headers",
+			responses: []limitedResponse{
+				{status: http.StatusTooManyRequests},
+				ok,
+			},
+			wantStatus: http.StatusOK, wantCalls: 2, wantWaits: []time.Duration{defaultSecondaryWait},
+		},
+		{
+			name: "403 secondary limit without Retry-After",
+			responses: []limitedResponse{
+				{
+					status:  http.StatusForbidden,
+					headers: map[string]string{"X-RateLimit-Remaining": "4000"},
+					body: `{"message":"You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",` +
+						`"documentation_url":"https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`,
+				},
+				ok,
+			},
+			wantStatus: http.StatusOK, wantCalls: 2, wantWaits: []time.Duration{defaultSecondaryWait},
+		},
+		{
+			name: "403 primary limit waits for the reset",
+			responses: []limitedResponse{
+				{status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}},
+				ok,
+			},
+			wantStatus: http.StatusOK, wantCalls: 2, wantWaits: []time.Duration{31 * time.Second},
+		},
+		{
+			name: "403 permission error is not retried",
+			responses: []limitedResponse{
+				{status: http.StatusForbidden, headers: map[string]string{"X-RateLimit-Remaining": "4999"}, body: `{"message":"Resource not accessible by integration"}`},
+			},
+			wantStatus: http.StatusForbidden, wantCalls: 1, wantBody: `{"message":"Resource not accessible by integration"}`,
+		},
+		{
+			name: "quota used up pauses before returning",
+			responses: []limitedResponse{
+				{status: http.StatusOK, headers: map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": reset}, body: `{"ok":true}`},
+			},
+			wantStatus: http.StatusOK, wantCalls: 1, wantWaits: []time.Duration{31 * time.Second}, wantBody: `{"ok":true}`,
+		},
+		{
+			name: "gives up after maxRateLimitRetries",
+			responses: []limitedResponse{
+				{status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "1"}},
+			},
+			wantStatus: http.StatusTooManyRequests, wantCalls: maxRateLimitRetries + 1,
+			wantWaits: []time.Duration{time.Second, time.Second, time.Second},
+		},
+		{
+			name: "wait beyond maxRateLimitWait is not retried",
+			responses: []limitedResponse{
+				{status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": strconv.Itoa(int((maxRateLimitWait + time.Minute).Seconds()))}},
+			},
+			wantStatus: http.StatusTooManyRequests, wantCalls: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, calls := rateLimitServer(t, tc.responses...)
+
+			var waits []time.Duration
+			transport := &rateLimitTransport{sleep: func(req *http.Request, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}}
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if got := calls.Load(); got != tc.wantCalls {
+				t.Errorf("server saw %d requests, want %d", got, tc.wantCalls)
+			}
+			if tc.wantBody != "" && string(body) != tc.wantBody {
+				t.Errorf("body = %q, want %q", body, tc.wantBody)
+			}
+			if len(waits) != len(tc.wantWaits) {
+				t.Fatalf("waits = %v, want %v", waits, tc.wantWaits)
+			}
+			for i, want := range tc.wantWaits {
+				if diff := waits[i] - want; diff < -2*time.Second || diff > 2*time.Second {
+					t.Errorf("wait %d = %v, want about %v", i, waits[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitTransportRetriesWithoutModifyingRequest(t *testing.T) {
+	var bodies []string
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"query":"q"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := req.Body
+	transport := &rateLimitTransport{sleep: func(*http.Request, time.Duration) error { return nil }}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != `{"query":"q"}` {
+		t.Errorf("status %d after bodies %q, want 200 after the same body twice", resp.StatusCode, bodies)
+	}
+	if req.Body != original {
+		t.Error("RoundTrip replaced the caller's request body")
+	}
+}
+
+func TestRateLimitTransportStopsOnCancel(t *testing.T) {
+	server, calls := rateLimitServer(t, limitedResponse{status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "60"}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	_, err = (&http.Client{Transport: &rateLimitTransport{}}).Do(req)
+	if !IsCancellation(err) {
+		t.Errorf("err = %v, want a context error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %v to notice the cancellation", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestSearchPRsLoadsConcurrentlyInOrder(t *testing.T) {
+	const hits, workers = 12, 3
+	var inFlight, peak atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		items := make([]string, hits)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"number":%d,"repository_url":"https://api.github.com/repos/acme/api"}`, i+1)
+		}
+		fmt.Fprintf(w, `{"total_count":%d,"items":[%s]}`, hits, strings.Join(items, ","))
+	})
+	mux.HandleFunc("/repos/acme/api/pulls/", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		number := strings.TrimPrefix(r.URL.Path, "/repos/acme/api/pulls/")
+		fmt.Fprintf(w, `{"number":%s,"title":"PR %s"}`, number, number)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	g := &GitHubPlugin{concurrency: workers}
+	prs, truncated, err := g.searchPRs(client, context.Background(), "is:pr author:octocat", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if truncated {
+		t.Error("truncated = true, want false")
+	}
+	if len(prs) != hits {
+		t.Fatalf("got %d PRs, want %d", len(prs), hits)
+	}
+	for i, pr := range prs {
+		if pr.GetNumber() != i+1 {
+			t.Fatalf("PR %d is #%d; search order was not kept", i, pr.GetNumber())
+		}
+	}
+	if p := peak.Load(); p < 2 || p > workers {
+		t.Errorf("peak concurrent PR loads = %d, want between 2 and %d", p, workers)
+	}
+}
+
+func TestSearchPRsStopsAtFirstFailure(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search/issues", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"total_count":3,"items":[`+
+			`{"number":1,"repository_url":"https://api.github.com/repos/acme/api"},`+
+			`{"number":2,"repository_url":"https://api.github.com/repos/acme/api"},`+
+			`{"number":3,"repository_url":"https://api.github.com/repos/acme/api"}]}`)
+	})
+	mux.HandleFunc("/repos/acme/api/pulls/", func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/2") {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		number := strings.TrimPrefix(r.URL.Path, "/repos/acme/api/pulls/")
+		fmt.Fprintf(w, `{"number":%s}`, number)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	prs, _, err := (&GitHubPlugin{}).searchPRs(client, context.Background(), "is:pr", 100)
+	if err == nil || !strings.Contains(err.Error(), "acme/api#2") {
+		t.Fatalf("err = %v, want the failure loading acme/api#2", err)
+	}
+	if len(prs) != 1 || prs[0].GetNumber() != 1 {
+		t.Errorf("prs = %v, want only #1", prs)
+	}
+}
@@ -0,0 +1,364 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+const githubReviewsUsage = "Usage: csync plugin exec github reviews [owner/repo] [--user LOGIN|@me] [--org ORG]... [--host NAME] " +
+	"[--since DATE] [--until DATE] [--max-prs N]"
+
+func (g *GitHubPlugin) executeReviews(ctx context.Context, args []string) error {
+	var search PRSearch
+	var user string
+	fs := newFlagSet("github reviews")
+	dates := addRangeFlags(fs)
+	fs.StringVar(&user, "user", "@me", "Whose review activity to collect")
+	fs.StringSliceVar(&search.Orgs, "org", nil, "Limit to these orgs (repeatable)")
+	fs.StringVar(&search.Host, "host", "", "Query this plugins.github.hosts entry instead of the default instance")
+	fs.IntVar(&g.maxPRs, "max-prs", g.maxPRs, "Stop after this many PRs/issues per search (default plugins.github.max_prs)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w\n%s", err, githubReviewsUsage)
+	}
+	r, err := dates.parse()
+	if err != nil {
+		return err
+	}
+	search.Since, search.Until = r.Since, r.Until
+
+	if fs.NArg() >= 1 {
+		if _, _, err := parseOwnerRepo(fs.Arg(0)); err != nil {
+			return err
+		}
+		search.Repos = []string{fs.Arg(0)}
+	}
+
+	host, err := g.host(search.Host)
+	if err != nil {
+		return err
+	}
+	client, err := g.newGitHubClient(ctx, search.Host)
+	if err != nil {
+		return err
+	}
+
+	login, err := resolveLogin(client, ctx, user)
+	if err != nil {
+		return err
+	}
+
+	logger.Logger.Info().Str("user", login).Str("scope", search.describe()).Msg("📌 Review Activity")
+	err = printSection(func() ([]contrib.Contribution, error) {
+		return host.qualify(g.collectReviewActivity(client, ctx, search, login))
+	}, func(activity []contrib.Contribution) { printReviewActivity(login, activity) })
+
+	if IsCancellation(err) {
+		fmt.Printf("\n⚠️ Stopped early (%v); showing partial results.\n", err)
+	}
+	return err
+}
+
+// resolveLogin turns "@me" into the authenticated user's login
+func resolveLogin(client *github.Client, ctx context.Context, login string) (string, error) {
+	if login != "@me" {
+		return login, nil
+	}
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the authenticated GitHub user: %w", err)
+	}
+	return user.GetLogin(), nil
+}
+
+// collectReviewActivity returns the reviews, inline review comments and
+// issue/PR conversation comments login made within the search's repos/orgs
+// and date range. PRs and issues are found via reviewed-by: and commenter:
+// searches, then their reviews and comments are filtered down to login's
+// within the date range.
+// On cancellation the activity gathered so far is returned alongside ctx.Err().
+func (g *GitHubPlugin) collectReviewActivity(client *github.Client, ctx context.Context, search PRSearch, login string) ([]contrib.Contribution, error) {
+	if login == "" {
+		return nil, errors.New("github: a login is required to collect review activity")
+	}
+	window := Query{Since: search.Since, Until: search.Until}
+	maxItems := g.prLimit()
+	reviewedQuery, commentedQuery := reviewActivityQueries(search, login)
+
+	reviewed, truncated, err := searchIssues(client, ctx, reviewedQuery, maxItems)
+	if err != nil {
+		return nil, err
+	}
+	if truncated {
+		g.warnTruncated("PRs reviewed by "+login, maxItems)
+	}
+
+	var activity []contrib.Contribution
+	for _, issue := range reviewed {
+		if err := ctx.Err(); err != nil {
+			return activity, err
+		}
+		owner, repo, err := issueRepo(issue)
+		if err != nil {
+			return activity, err
+		}
+
+		reviews, err := fetchReviews(client, ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			return activity, err
+		}
+		for _, review := range reviews {
+			if activityBy(review.GetUser().GetLogin(), review.GetSubmittedAt().Time, login, window) {
+				activity = append(activity, reviewContribution(owner, repo, issue, review))
+			}
+		}
+
+		comments, err := fetchReviewComments(client, ctx, owner, repo, issue.GetNumber())
+		if err != nil {
+			return activity, err
+		}
+		for _, comment := range comments {
+			if activityBy(comment.GetUser().GetLogin(), comment.GetCreatedAt().Time, login, window) {
+				activity = append(activity, reviewCommentContribution(owner, repo, issue, comment))
+			}
+		}
+	}
+
+	commented, truncated, err := searchIssues(client, ctx, commentedQuery, maxItems)
+	if err != nil {
+		return activity, err
+	}
+	if truncated {
+		g.warnTruncated("issues and PRs commented on by "+login, maxItems)
+	}
+
+	for _, issue := range commented {
+		if err := ctx.Err(); err != nil {
+			return activity, err
+		}
+		owner, repo, err := issueRepo(issue)
+		if err != nil {
+			return activity, err
+		}
+
+		comments, err := fetchIssueComments(client, ctx, owner, repo, issue.GetNumber(), search)
+		if err != nil {
+			return activity, err
+		}
+		for _, comment := range comments {
+			if activityBy(comment.GetUser().GetLogin(), comment.GetCreatedAt().Time, login, window) {
+				activity = append(activity, issueCommentContribution(owner, repo, issue, comment))
+			}
+		}
+	}
+
+	return activity, nil
+}
+
+// reviewActivityQueries renders the searches for PRs login reviewed and for
+// issues and PRs login commented on. Only the start of the date range bounds
+// them: a PR reviewed within the range may have been updated after it ends,
+// so the end is applied to each review and comment by activityBy instead.
+func reviewActivityQueries(search PRSearch, login string) (reviewed, commented string) {
+	reviewSearch := PRSearch{Repos: search.Repos, Orgs: search.Orgs, ReviewedBy: login, Since: search.Since}
+
+	terms := append(search.scopeTerms(), "commenter:"+login)
+	if qualifier := dateQualifier("updated", search.Since, time.Time{}); qualifier != "" {
+		terms = append(terms, qualifier)
+	}
+	return reviewSearch.query(), strings.Join(terms, " ")
+}
+
+// activityBy reports whether a review or comment by author at the given time
+// is login's and falls within window
+func activityBy(author string, at time.Time, login string, window Query) bool {
+	return strings.EqualFold(author, login) && window.InRange(at)
+}
+
+func fetchReviews(client *github.Client, ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+
+	var reviews []*github.PullRequestReview
+	for {
+		page, resp, err := client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching reviews for %s/%s#%d: %w", owner, repo, number, err)
+		}
+		reviews = append(reviews, page...)
+
+		if resp.NextPage == 0 {
+			return reviews, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func fetchReviewComments(client *github.Client, ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error) {
+	opts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var comments []*github.PullRequestComment
+	for {
+		page, resp, err := client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching review comments for %s/%s#%d: %w", owner, repo, number, err)
+		}
+		comments = append(comments, page...)
+
+		if resp.NextPage == 0 {
+			return comments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+func fetchIssueComments(client *github.Client, ctx context.Context, owner, repo string, number int, search PRSearch) ([]*github.IssueComment, error) {
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	if !search.Since.IsZero() {
+		opts.Since = &search.Since
+	}
+
+	var comments []*github.IssueComment
+	for {
+		page, resp, err := client.Issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching comments for %s/%s#%d: %w", owner, repo, number, err)
+		}
+		comments = append(comments, page...)
+
+		if resp.NextPage == 0 {
+			return comments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// parentLink links review activity to the PR or issue it belongs to
+func parentLink(issue *github.Issue) contrib.Link {
+	rel := "issue"
+	if issue.IsPullRequest() {
+		rel = "pull_request"
+	}
+	return contrib.Link{Rel: rel, Target: strconv.Itoa(issue.GetNumber()), URL: issue.GetHTMLURL()}
+}
+
+func reviewContribution(owner, repo string, issue *github.Issue, review *github.PullRequestReview) contrib.Contribution {
+	submitted := review.GetSubmittedAt().Time
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindReview,
+		ID:        strconv.FormatInt(review.GetID(), 10),
+		Project:   owner + "/" + repo,
+		Title:     issue.GetTitle(),
+		URL:       review.GetHTMLURL(),
+		Authors:   []contrib.Identity{{Login: review.GetUser().GetLogin()}},
+		Status:    strings.ToLower(review.GetState()),
+		CreatedAt: submitted,
+		UpdatedAt: submitted,
+		Links:     []contrib.Link{parentLink(issue)},
+		Metadata:  map[string]any{"body": review.GetBody()},
+	}
+}
+
+func reviewCommentContribution(owner, repo string, issue *github.Issue, comment *github.PullRequestComment) contrib.Contribution {
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindReviewComment,
+		ID:        strconv.FormatInt(comment.GetID(), 10),
+		Project:   owner + "/" + repo,
+		Title:     issue.GetTitle(),
+		URL:       comment.GetHTMLURL(),
+		Authors:   []contrib.Identity{{Login: comment.GetUser().GetLogin()}},
+		CreatedAt: comment.GetCreatedAt().Time,
+		UpdatedAt: comment.GetUpdatedAt().Time,
+		Links:     []contrib.Link{parentLink(issue)},
+		Metadata:  map[string]any{"body": comment.GetBody(), "path": comment.GetPath()},
+	}
+}
+
+func issueCommentContribution(owner, repo string, issue *github.Issue, comment *github.IssueComment) contrib.Contribution {
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindComment,
+		ID:        strconv.FormatInt(comment.GetID(), 10),
+		Project:   owner + "/" + repo,
+		Title:     issue.GetTitle(),
+		URL:       comment.GetHTMLURL(),
+		Authors:   []contrib.Identity{{Login: comment.GetUser().GetLogin()}},
+		CreatedAt: comment.GetCreatedAt().Time,
+		UpdatedAt: comment.GetUpdatedAt().Time,
+		Links:     []contrib.Link{parentLink(issue)},
+		Metadata:  map[string]any{"body": comment.GetBody()},
+	}
+}
+
+// reviewCounts tallies review activity for one repo
+type reviewCounts struct {
+	approved, changesRequested, commented, reviewComments, comments int
+}
+
+func printReviewActivity(login string, activity []contrib.Contribution) {
+	fmt.Printf("\n📌 Review activity for **%s**\n", login)
+	if len(activity) == 0 {
+		fmt.Println("\n❌ No review activity found.")
+		return
+	}
+
+	byRepo := make(map[string][]contrib.Contribution)
+	for _, c := range activity {
+		byRepo[c.Project] = append(byRepo[c.Project], c)
+	}
+
+	repos := make([]string, 0, len(byRepo))
+	for repo := range byRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	for _, repo := range repos {
+		var counts reviewCounts
+		for _, c := range byRepo[repo] {
+			switch {
+			case c.Kind == contrib.KindReview && c.Status == "approved":
+				counts.approved++
+			case c.Kind == contrib.KindReview && c.Status == "changes_requested":
+				counts.changesRequested++
+			case c.Kind == contrib.KindReview:
+				counts.commented++
+			case c.Kind == contrib.KindReviewComment:
+				counts.reviewComments++
+			case c.Kind == contrib.KindComment:
+				counts.comments++
+			}
+		}
+
+		fmt.Printf("\n📁 %s: ✅ %d approved | 🔁 %d changes requested | 💬 %d commented reviews | 🧵 %d review comments | 🗨️ %d comments\n",
+			repo, counts.approved, counts.changesRequested, counts.commented, counts.reviewComments, counts.comments)
+		for _, c := range byRepo[repo] {
+			fmt.Printf("   - %s #%s %s · %s\n", reviewActivityLabel(c), c.Links[0].Target, c.Title, c.URL)
+		}
+	}
+}
+
+func reviewActivityLabel(c contrib.Contribution) string {
+	switch c.Kind {
+	case contrib.KindReview:
+		switch c.Status {
+		case "approved":
+			return "✅ Approved"
+		case "changes_requested":
+			return "🔁 Requested changes on"
+		}
+		return "💬 Reviewed"
+	case contrib.KindReviewComment:
+		return "🧵 Review comment on"
+	}
+	return "🗨️ Commented on"
+}
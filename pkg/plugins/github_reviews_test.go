@@ -0,0 +1,87 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewActivityQueries(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		search        PRSearch
+		wantReviewed  string
+		wantCommented string
+	}{
+		{
+			name:          "no range",
+			search:        PRSearch{},
+			wantReviewed:  "is:pr reviewed-by:octocat",
+			wantCommented: "commenter:octocat",
+		},
+		{
+			// The end of the range is left to the per-review filter
+			name:          "range",
+			search:        PRSearch{Since: since, Until: until},
+			wantReviewed:  "is:pr reviewed-by:octocat updated:>=2026-07-01T00:00:00Z",
+			wantCommented: "commenter:octocat updated:>=2026-07-01T00:00:00Z",
+		},
+		{
+			name:          "until only",
+			search:        PRSearch{Until: until},
+			wantReviewed:  "is:pr reviewed-by:octocat",
+			wantCommented: "commenter:octocat",
+		},
+		{
+			// Author and merge filters describe the user's own PRs, not the ones they reviewed
+			name:          "scoped",
+			search:        PRSearch{Repos: []string{"acme/api"}, Orgs: []string{"acme"}, Author: "someone", MergedOnly: true, Since: since},
+			wantReviewed:  "is:pr repo:acme/api org:acme reviewed-by:octocat updated:>=2026-07-01T00:00:00Z",
+			wantCommented: "repo:acme/api org:acme commenter:octocat updated:>=2026-07-01T00:00:00Z",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reviewed, commented := reviewActivityQueries(tc.search, "octocat")
+			if reviewed != tc.wantReviewed {
+				t.Errorf("reviewed query = %q, want %q", reviewed, tc.wantReviewed)
+			}
+			if commented != tc.wantCommented {
+				t.Errorf("commented query = %q, want %q", commented, tc.wantCommented)
+			}
+		})
+	}
+}
+
+func TestActivityBy(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC)
+	window := Query{Since: since, Until: until}
+
+	tests := []struct {
+		name   string
+		author string
+		at     time.Time
+		window Query
+		want   bool
+	}{
+		{name: "inside", author: "octocat", at: since.Add(24 * time.Hour), window: window, want: true},
+		{name: "login case", author: "OctoCat", at: since.Add(24 * time.Hour), window: window, want: true},
+		{name: "on the start", author: "octocat", at: since, window: window, want: true},
+		{name: "on the end", author: "octocat", at: until, window: window, want: true},
+		{name: "before", author: "octocat", at: since.Add(-time.Second), window: window, want: false},
+		// A PR reviewed in the range but updated after it is still found; the review itself decides
+		{name: "after", author: "octocat", at: until.Add(time.Second), window: window, want: false},
+		{name: "someone else", author: "hubot", at: since.Add(24 * time.Hour), window: window, want: false},
+		{name: "open range", author: "octocat", at: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), want: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := activityBy(tc.author, tc.at, "octocat", tc.window); got != tc.want {
+				t.Errorf("activityBy(%q, %v) = %v, want %v", tc.author, tc.at, got, tc.want)
+			}
+		})
+	}
+}
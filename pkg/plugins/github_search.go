@@ -0,0 +1,106 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PRSearch selects the pull requests a GitHub summary covers. Repos alone are
+// listed directly; anything else goes through the search API, which can match
+// PRs across every repo a user touched.
+type PRSearch struct {
+	Host       string   // plugins.github.hosts name; empty for the default instance
+	Repos      []string // owner/repo
+	Orgs       []string
+	Author     string // GitHub login, or @me for the token's user
+	ReviewedBy string
+	Involves   string
+	MergedOnly bool // Only merged PRs, with the date range applied to the merge date
+	Since      time.Time
+	Until      time.Time
+}
+
+// usesSearch reports whether the PRs must come from the search API rather than repo listings
+func (s PRSearch) usesSearch() bool {
+	return len(s.Orgs) > 0 || s.Author != "" || s.ReviewedBy != "" || s.Involves != "" ||
+		s.MergedOnly || !s.Since.IsZero() || !s.Until.IsZero()
+}
+
+// query renders the search API query, e.g. "is:pr author:@me org:acme updated:>=2026-07-01T00:00:00Z"
+func (s PRSearch) query() string {
+	terms := append([]string{"is:pr"}, s.scopeTerms()...)
+	if s.Author != "" {
+		terms = append(terms, "author:"+s.Author)
+	}
+	if s.ReviewedBy != "" {
+		terms = append(terms, "reviewed-by:"+s.ReviewedBy)
+	}
+	if s.Involves != "" {
+		terms = append(terms, "involves:"+s.Involves)
+	}
+
+	dateField := "updated"
+	if s.MergedOnly {
+		terms = append(terms, "is:merged")
+		dateField = "merged"
+	}
+	if qualifier := dateQualifier(dateField, s.Since, s.Until); qualifier != "" {
+		terms = append(terms, qualifier)
+	}
+	return strings.Join(terms, " ")
+}
+
+// scopeTerms renders the repo: and org: qualifiers
+func (s PRSearch) scopeTerms() []string {
+	var terms []string
+	for _, repo := range s.Repos {
+		terms = append(terms, "repo:"+repo)
+	}
+	for _, org := range s.Orgs {
+		terms = append(terms, "org:"+org)
+	}
+	return terms
+}
+
+// describe names what a search covers for headings and logs
+func (s PRSearch) describe() string {
+	var parts []string
+	if len(s.Repos) > 0 {
+		parts = append(parts, strings.Join(s.Repos, ", "))
+	}
+	if len(s.Orgs) > 0 {
+		parts = append(parts, "org "+strings.Join(s.Orgs, ", "))
+	}
+	if s.Author != "" {
+		parts = append(parts, "author "+s.Author)
+	}
+	if s.ReviewedBy != "" {
+		parts = append(parts, "reviewed by "+s.ReviewedBy)
+	}
+	if s.Involves != "" {
+		parts = append(parts, "involving "+s.Involves)
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "all accessible repos")
+	}
+	if s.Host != "" {
+		parts = append(parts, "host "+s.Host)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// dateQualifier renders a search qualifier such as updated:2026-07-01T00:00:00Z..2026-09-30T23:59:59Z
+func dateQualifier(field string, since, until time.Time) string {
+	format := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+
+	switch {
+	case !since.IsZero() && !until.IsZero():
+		return fmt.Sprintf("%s:%s..%s", field, format(since), format(until))
+	case !since.IsZero():
+		return fmt.Sprintf("%s:>=%s", field, format(since))
+	case !until.IsZero():
+		return fmt.Sprintf("%s:<=%s", field, format(until))
+	}
+	return ""
+}
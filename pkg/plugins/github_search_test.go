@@ -0,0 +1,79 @@
+package plugins
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPRSearchQuery(t *testing.T) {
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		search     PRSearch
+		want       string
+		wantSearch bool
+	}{
+		{name: "repos only", search: PRSearch{Repos: []string{"acme/api"}}, want: "is:pr repo:acme/api"},
+		{
+			name:       "author across orgs",
+			search:     PRSearch{Orgs: []string{"acme", "labs"}, Author: "@me"},
+			want:       "is:pr org:acme org:labs author:@me",
+			wantSearch: true,
+		},
+		{
+			name:       "reviewer and involvement",
+			search:     PRSearch{Repos: []string{"acme/api"}, ReviewedBy: "octocat", Involves: "hubot"},
+			want:       "is:pr repo:acme/api reviewed-by:octocat involves:hubot",
+			wantSearch: true,
+		},
+		{
+			name:       "updated in range",
+			search:     PRSearch{Repos: []string{"acme/api"}, Since: since, Until: until},
+			want:       "is:pr repo:acme/api updated:2026-07-01T00:00:00Z..2026-09-30T23:59:59Z",
+			wantSearch: true,
+		},
+		{
+			name:       "merged since",
+			search:     PRSearch{Author: "octocat", MergedOnly: true, Since: since},
+			want:       "is:pr author:octocat is:merged merged:>=2026-07-01T00:00:00Z",
+			wantSearch: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.search.query(); got != tc.want {
+				t.Errorf("query = %q, want %q", got, tc.want)
+			}
+			if got := tc.search.usesSearch(); got != tc.wantSearch {
+				t.Errorf("usesSearch = %v, want %v", got, tc.wantSearch)
+			}
+		})
+	}
+}
+
+func TestDateQualifier(t *testing.T) {
+	// Bounds are written in UTC whatever zone they come in
+	eastern := time.FixedZone("EDT", -4*60*60)
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, eastern)
+	until := time.Date(2026, 9, 30, 23, 59, 59, 0, eastern)
+
+	tests := []struct {
+		name         string
+		since, until time.Time
+		want         string
+	}{
+		{name: "range", since: since, until: until, want: "updated:2026-07-01T04:00:00Z..2026-10-01T03:59:59Z"},
+		{name: "since", since: since, want: "updated:>=2026-07-01T04:00:00Z"},
+		{name: "until", until: until, want: "updated:<=2026-10-01T03:59:59Z"},
+		{name: "unbounded", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := dateQualifier("updated", tc.since, tc.until); got != tc.want {
+				t.Errorf("dateQualifier = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/ibexmonj/ContribSync/config"
+)
+
+// pullsServer lists PRs 1..len(updated) for acme/api in the given order, pageSize per page
+func pullsServer(t *testing.T, updated []time.Time, pageSize int) (*github.Client, *int) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("sort") != "updated" || query.Get("direction") != "desc" || query.Get("state") != "all" {
+			t.Errorf("listed PRs with %s, want all PRs by updated desc", r.URL.RawQuery)
+		}
+		pages++
+		page, _ := strconv.Atoi(query.Get("page"))
+		page = max(page, 1)
+		start, end := (page-1)*pageSize, min(page*pageSize, len(updated))
+		if end < len(updated) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/acme/api/pulls?page=%d>; rel="next"`, "http://"+r.Host, page+1))
+		}
+
+		var prs []string
+		for i := start; i < end; i++ {
+			prs = append(prs, fmt.Sprintf(`{"number":%d,"updated_at":%q}`, i+1, updated[i].Format(time.RFC3339)))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(prs, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return client, &pages
+}
+
+func TestFetchPRs(t *testing.T) {
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	// Most recently updated first, as requested
+	updated := []time.Time{start.Add(9 * day), start.Add(5 * day), start.Add(day), start.Add(-day), start.Add(-9 * day)}
+
+	tests := []struct {
+		name          string
+		maxPRs        int
+		wantPRs       int
+		wantTruncated bool
+		wantPages     int
+	}{
+		{name: "reads everything", maxPRs: 10, wantPRs: 5, wantPages: 3},
+		{name: "cap equals the PR count", maxPRs: 5, wantPRs: 5, wantPages: 3},
+		{name: "capped", maxPRs: 4, wantPRs: 4, wantTruncated: true, wantPages: 3},
+		{name: "capped at a page end", maxPRs: 2, wantPRs: 2, wantTruncated: true, wantPages: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, pages := pullsServer(t, updated, 2)
+
+			prs, truncated, err := fetchPRs(client, context.Background(), "acme", "api", tc.maxPRs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(prs) != tc.wantPRs || truncated != tc.wantTruncated {
+				t.Errorf("got %d PRs, truncated %v; want %d, %v", len(prs), truncated, tc.wantPRs, tc.wantTruncated)
+			}
+			if *pages != tc.wantPages {
+				t.Errorf("read %d pages, want %d", *pages, tc.wantPages)
+			}
+		})
+	}
+}
+
+func TestCollectContributionsRESTCommitsFailure(t *testing.T) {
+	fake := &fakeGitHub{
+		t: t,
+		rest: map[string]string{
+			"/repos/acme/api/pulls": `[
+				{"number":5,"title":"Listed","state":"open","updated_at":"2026-08-01T12:00:00Z","base":{"repo":{"name":"api","owner":{"login":"acme"}}}},
+				{"number":6,"title":"Unlisted","state":"open","updated_at":"2026-08-01T11:00:00Z","base":{"repo":{"name":"api","owner":{"login":"acme"}}}}
+			]`,
+			"/repos/acme/api/pulls/5/commits": `[{"sha":"ddd4444","commit":{"message":"Fix","author":{"email":"me@example.com"}}}]`,
+		},
+	}
+	g := &GitHubPlugin{}
+
+	contributions, err := g.collectContributions(fake.client(), context.Background(), PRSearch{Repos: []string{"acme/api"}}, nil)
+	if err == nil || !strings.Contains(err.Error(), "acme/api#6") {
+		t.Errorf("err = %v, want the failed commit listing of acme/api#6", err)
+	}
+	if len(contributions) != 2 || contributions[0].ID != "5" || contributions[1].ID != "ddd4444" {
+		t.Errorf("contributions = %+v, want PR 5 and its commit", contributions)
+	}
+}
+
+func TestHostTargets(t *testing.T) {
+	var cfg config.Config
+	cfg.Plugins.GitHub.Repos = []string{"acme/api"}
+	cfg.Plugins.GitHub.Hosts = []config.GitHubHost{
+		{Name: "ghes", BaseURL: "https://ghe.acme.dev/api/v3", Repos: []string{"platform/deploy", "platform/infra"}},
+		{Name: "lab", BaseURL: "https://lab.acme.dev/api/v3"},
+	}
+	g := &GitHubPlugin{hosts: loadGitHubHosts(&cfg)}
+
+	tests := []struct {
+		name    string
+		targets []string
+		want    map[string][]string
+	}{
+		{
+			name: "configured repos without targets",
+			want: map[string][]string{"": {"acme/api"}, "ghes": {"platform/deploy", "platform/infra"}},
+		},
+		{
+			name:    "targets replace the configured repos",
+			targets: []string{"acme/web", "lab:research/notebooks"},
+			want:    map[string][]string{"": {"acme/web"}, "lab": {"research/notebooks"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := g.hostTargets(Query{Targets: tc.targets})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.EqualFunc(got, tc.want, slices.Equal) {
+				t.Errorf("hostTargets = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := g.hostTargets(Query{Targets: []string{"nope:acme/api"}}); err == nil {
+		t.Error("hostTargets accepted an unknown host")
+	}
+}
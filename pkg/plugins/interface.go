@@ -1,7 +1,108 @@
 package plugins
 
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
 type Plugin interface {
-	Init() error                             // Initialize the plugin
-	Execute(args []string) error             // Execute plugin-specific commands
-	Info() (name string, description string) // Return plugin name and description
+	Init() error                                      // Initialize the plugin
+	Execute(ctx context.Context, args []string) error // Execute plugin-specific commands
+	Info() (name string, description string)          // Return plugin name and description
+}
+
+// LegacyPlugin is the interface external plugins were built against before
+// Execute took a context. The manager never called Init on them, so they
+// initialize themselves inside Execute.
+type LegacyPlugin interface {
+	Init() error
+	Execute(args []string) error
+	Info() (name string, description string)
+}
+
+// legacyPlugin adapts a LegacyPlugin to Plugin. Init is a no-op because the
+// plugin already initializes itself in Execute, and ctx is dropped.
+type legacyPlugin struct {
+	LegacyPlugin
+}
+
+func (l legacyPlugin) Init() error {
+	return nil
+}
+
+func (l legacyPlugin) Execute(ctx context.Context, args []string) error {
+	return l.LegacyPlugin.Execute(args)
+}
+
+// Source is implemented by plugins that can return contributions as data
+// instead of printing them, so they can be driven by reports or other programs.
+type Source interface {
+	Plugin
+	Fetch(ctx context.Context, q Query) ([]contrib.Contribution, error)
+}
+
+// Query narrows down what a Source fetches. Zero values mean "no restriction".
+type Query struct {
+	Identities []contrib.Identity // Whose contributions to fetch
+	Since      time.Time          // Only items updated at or after this time
+	Until      time.Time          // Only items updated at or before this time
+	Targets    []string           // owner/repo for GitHub, project keys for Jira
+	Limit      int                // Maximum number of contributions to return
+	Named      string             // A query saved in the source's config (e.g. plugins.jira.queries) to run instead
+}
+
+// InRange reports whether t falls inside the query's date range
+func (q Query) InRange(t time.Time) bool {
+	if !q.Since.IsZero() && t.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && t.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// Emails returns the non-empty email addresses of the query identities
+func (q Query) Emails() []string {
+	var emails []string
+	for _, id := range q.Identities {
+		if id.Email != "" {
+			emails = append(emails, id.Email)
+		}
+	}
+	return emails
+}
+
+// finish applies the query limit to a result set. A fetch that found more
+// than the limit, or that a source's own cap stopped with items left unread
+// (capped), is reported as ErrTruncated unless err is set. Exactly reaching
+// the limit isn't, as nothing was left behind.
+func (q Query) finish(contributions []contrib.Contribution, capped bool, err error) ([]contrib.Contribution, error) {
+	if q.Limit > 0 && len(contributions) > q.Limit {
+		contributions = contributions[:q.Limit]
+		capped = true
+	}
+	if capped && err == nil {
+		err = ErrTruncated
+	}
+	return contributions, err
+}
+
+// ErrTruncated is returned by Fetch, along with what it did collect, when
+// Query.Limit or a source cap such as plugins.github.max_prs stopped it before
+// every matching item was read
+var ErrTruncated = errors.New("results truncated by a limit")
+
+// IsTruncated reports whether err means a fetch was cut short by a limit
+func IsTruncated(err error) bool {
+	return errors.Is(err, ErrTruncated)
+}
+
+// IsCancellation reports whether err was caused by the context being cancelled
+// or timing out, in which case callers may still hold partial results
+func IsCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
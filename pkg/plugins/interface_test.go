@@ -0,0 +1,41 @@
+package plugins
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+func TestQueryFinish(t *testing.T) {
+	three := make([]contrib.Contribution, 3)
+	failed := errors.New("boom")
+
+	tests := []struct {
+		name    string
+		limit   int
+		capped  bool
+		err     error
+		wantLen int
+		wantErr error
+	}{
+		{name: "no limit", wantLen: 3},
+		{name: "under limit", limit: 5, wantLen: 3},
+		{name: "at limit", limit: 3, wantLen: 3},
+		{name: "at limit with more left", limit: 3, capped: true, wantLen: 3, wantErr: ErrTruncated},
+		{name: "over limit", limit: 2, wantLen: 2, wantErr: ErrTruncated},
+		{name: "source cap", capped: true, wantLen: 3, wantErr: ErrTruncated},
+		{name: "error wins", limit: 2, err: failed, wantLen: 2, wantErr: failed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Query{Limit: tc.limit}.finish(three, tc.capped, tc.err)
+			if len(got) != tc.wantLen {
+				t.Errorf("got %d contributions, want %d", len(got), tc.wantLen)
+			}
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tc.wantErr)
+			}
+		})
+	}
+}
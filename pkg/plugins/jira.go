@@ -1,32 +1,72 @@
 package plugins
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/adf"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/llm"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/prompt"
+	"github.com/ibexmonj/ContribSync/pkg/summary"
+	"github.com/spf13/pflag"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // JiraPlugin represents the Jira integration plugin
 type JiraPlugin struct {
-	baseURL  string
-	apiToken string
-	email    string
+	baseURL    string
+	apiToken   string
+	email      string
+	auth       string
+	cloudID    string
+	apiVersion int    // REST API version: 2, or 3 for Atlassian Document Format text
+	creditBy   string // activity or assignee
+	queries    map[string]string
+	fields     []jiraField // Cached field definitions, see loadFields
+	agile      *jiraAgileFields
+	// storyPoints names the story points field; empty tries the usual names
+	storyPoints string
+	location    *time.Location // Cached profile time zone, see jqlLocation
+	truncated   bool           // Set when a search stopped at its limit with issues left, see searchRaw
 }
 
+// LoadEnvVars reads the site URL (JIRA_BASE_URL, else plugins.jira.base_url)
+// and the credentials for the auth mode in plugins.jira.auth
 func (p *JiraPlugin) LoadEnvVars() error {
+	jiraConfig := config.ConfigData.Plugins.Jira
 	p.baseURL = os.Getenv("JIRA_BASE_URL")
-	p.email = os.Getenv("JIRA_EMAIL")
-	p.apiToken = os.Getenv("JIRA_API_TOKEN")
+	if p.baseURL == "" {
+		p.baseURL = jiraConfig.BaseURL
+	}
+	p.auth = jiraConfig.Auth
+	p.cloudID = jiraConfig.CloudID
+	p.apiVersion = jiraConfig.APIVersion
+	p.creditBy = jiraConfig.CreditBy
+	p.queries = jiraConfig.Queries
+	p.storyPoints = jiraConfig.StoryPointsField
+	if p.creditBy != jiraCreditAssignee {
+		p.creditBy = jiraCreditActivity
+	}
+	if p.apiVersion != 3 {
+		p.apiVersion = 2
+	}
 
-	if p.baseURL == "" || p.apiToken == "" || p.email == "" {
-		return fmt.Errorf("missing required environment variables")
+	if p.baseURL == "" {
+		return fmt.Errorf("missing required environment variables: JIRA_BASE_URL is not set")
+	}
+	if err := p.loadCredentials(); err != nil {
+		return fmt.Errorf("missing required environment variables: %w", err)
 	}
 	return nil
 }
@@ -50,52 +90,27 @@ func (p *JiraPlugin) Init() error {
 	if err := p.LoadEnvVars(); err != nil {
 		return err
 	}
-	logger.Logger.Info().Str("Base URL", p.baseURL).Msg("Jira plugin initialized")
+	logger.Logger.Info().Str("Base URL", p.baseURL).Str("Auth", p.auth).Int("API Version", p.apiVersion).Msg("Jira plugin initialized")
 	return nil
 }
 
-func (p *JiraPlugin) makeRequest(method, endpoint string, body io.Reader, isOpenAI bool) (*http.Response, error) {
-	var fullURL string
-	reqHeaders := make(map[string]string)
-
-	if strings.HasPrefix(endpoint, "http") {
-		fullURL = endpoint
-	} else {
-		fullURL = p.baseURL + endpoint
-	}
-
-	req, err := http.NewRequest(method, fullURL, body)
+func (p *JiraPlugin) makeRequest(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.apiURL()+endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-
-	if isOpenAI {
-		apiKey := os.Getenv("OPENAI_API_KEY")
-		orgID := os.Getenv("OPENAI_ORG")
-
-		if apiKey == "" {
-			return nil, fmt.Errorf("OPENAI_API_KEY is not set. Please export your API key.")
-		}
-
-		reqHeaders["Authorization"] = "Bearer " + apiKey
-		reqHeaders["Content-Type"] = "application/json"
-		if orgID != "" {
-			reqHeaders["OpenAI-Organization"] = orgID
-		}
-	} else {
-		reqHeaders["Authorization"] = "Basic " + p.apiToken
-		reqHeaders["Content-Type"] = "application/json"
-	}
-
-	for key, value := range reqHeaders {
-		req.Header.Set(key, value)
-	}
+	p.authorize(req)
+	req.Header.Set("Content-Type", "application/json")
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
+	if err := p.credentialsError(resp); err != nil {
+		HandleResponseBody(resp.Body)
+		return nil, err
+	}
 	return resp, nil
 }
 
@@ -104,313 +119,685 @@ func HandleResponseBody(body io.ReadCloser) {
 		fmt.Println("Warning: failed to close response body:", err)
 	}
 }
-func (p *JiraPlugin) Execute(args []string) error {
+func (p *JiraPlugin) Execute(ctx context.Context, args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("no arguments provided")
 	}
 
 	switch args[0] {
 	case "create-issue":
-		if len(args) < 4 {
-			return fmt.Errorf("usage: create-issue <projectKey> <summary> <description>")
+		return p.executeCreateIssue(ctx, args[1:])
+	case "bulk-create":
+		return p.executeBulkCreate(ctx, args[1:])
+	case "show":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: show <issueKey>")
 		}
-		return p.CreateIssue(args[1], args[2], args[3])
+		return p.showIssue(ctx, args[1])
 	case "list-issues":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: list-issues <projectKey>")
+		projectKey, limit, err := parseProjectArgs(args[1:])
+		if err != nil {
+			return err
 		}
-		return p.listIssues(args[1])
+		return p.listIssues(ctx, projectKey, limit)
 	case "assigned-issues":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: assigned-issues <userEmail>")
+		userEmail, r, limit, err := parseUserRangeArgs("assigned-issues", args[1:])
+		if err != nil {
+			return err
 		}
-		return p.assignedIssues(args[1])
+		return p.assignedIssues(ctx, userEmail, r, limit)
+	case "search":
+		return p.executeSearch(ctx, args[1:])
+	case "filter":
+		return p.executeFilter(ctx, args[1:])
+	case "rollup":
+		userEmail, r, limit, err := parseUserRangeArgs("rollup", args[1:])
+		if err != nil {
+			return err
+		}
+		return p.rollup(ctx, userEmail, r, limit)
+	case "activity":
+		userEmail, r, limit, err := parseUserRangeArgs("activity", args[1:])
+		if err != nil {
+			return err
+		}
+		return p.activity(ctx, userEmail, r, limit)
 	case "summary":
-		if len(args) < 2 {
-			return fmt.Errorf("usage: summary <userEmail>")
+		fs := newFlagSet("jira summary")
+		opts := summary.OptionsFromConfig(&config.ConfigData)
+		fs.StringVar(&opts.GroupBy, "group-by", opts.GroupBy, "Group large sets by project, epic or month before summarizing")
+		fs.IntVar(&opts.MaxTokens, "max-tokens", opts.MaxTokens, "Length of the summary (default llm.max_tokens)")
+		userEmail, r, limit, err := parseUserRangeFlags("summary", fs, args[1:])
+		if err != nil {
+			return err
 		}
 
-		issues, err := p.fetchAssignedIssues(args[1])
+		issues, err := p.fetchUserIssues(ctx, userEmail, r, limit, true)
 		if err != nil {
 			return err
 		}
 
-		return p.generateAISummary(args[1], issues)
+		return p.generateAISummary(ctx, userEmail, r, issues, opts)
 	default:
 		return fmt.Errorf("unknown Jira command: %s", args[0])
 	}
 }
 
-func (p *JiraPlugin) Info() (string, string) {
-	return "jira", "Integration with Jira for tracking issues"
+// parseUserRangeArgs parses "<userEmail> [--since DATE] [--until DATE] [--limit N]"
+func parseUserRangeArgs(command string, args []string) (string, daterange.Range, int, error) {
+	return parseUserRangeFlags(command, newFlagSet("jira "+command), args)
 }
 
-func (j *JiraPlugin) CreateIssue(projectKey, summary, description string) error {
-	issue := map[string]interface{}{
-		"fields": map[string]interface{}{
-			"project":     map[string]string{"key": projectKey},
-			"summary":     summary,
-			"description": description,
-			"issuetype":   map[string]string{"name": "Task"},
-		},
-	}
+// parseUserRangeFlags is parseUserRangeArgs for commands with flags of their own, already added to fs
+func parseUserRangeFlags(command string, fs *pflag.FlagSet, args []string) (string, daterange.Range, int, error) {
+	usage := fmt.Errorf("usage: %s <userEmail> [--since DATE] [--until DATE] [--limit N]", command)
 
-	body, err := toJSON(issue)
-	if err != nil {
-		return wrapError("failed to prepare issue payload", err)
+	dates := addRangeFlags(fs)
+	limit := addLimitFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return "", daterange.Range{}, 0, fmt.Errorf("%v\n%w", err, usage)
+	}
+	if fs.NArg() < 1 {
+		return "", daterange.Range{}, 0, usage
 	}
 
-	resp, err := j.makeRequest("POST", "/rest/api/2/issue", bytes.NewReader(body), false)
+	r, err := dates.parse()
 	if err != nil {
-		return wrapError("failed to create Jira issue", err)
+		return "", daterange.Range{}, 0, err
 	}
+	return fs.Arg(0), r, *limit, nil
+}
 
-	defer HandleResponseBody(resp.Body)
-	respBody, _ := io.ReadAll(resp.Body)
-	logger.Logger.Debug().Str("JiraResponse", string(respBody)).Msg("Jira API response")
-	if resp.StatusCode != http.StatusCreated {
-		return fmt.Errorf("unexpected response from Jira: %s", resp.Status)
+// parseProjectArgs parses "<projectKey> [--limit N]"
+func parseProjectArgs(args []string) (string, int, error) {
+	usage := errors.New("usage: list-issues <projectKey> [--limit N]")
+
+	fs := newFlagSet("jira list-issues")
+	limit := addLimitFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return "", 0, fmt.Errorf("%v\n%w", err, usage)
+	}
+	if fs.NArg() < 1 {
+		return "", 0, usage
 	}
+	return fs.Arg(0), *limit, nil
+}
 
-	return nil
+// Fetch returns the query identities' issues, restricted to the query's
+// projects and date range. By default an issue counts when the changelog shows
+// they worked on it in the range (see creditReasons); with credit_by: assignee
+// it counts when they are its assignee. A query with neither identities nor
+// projects falls back to the current user rather than searching all of Jira,
+// and one with only projects lists every issue in them. A query naming one of
+// plugins.jira.queries runs that JQL instead, limited to the date range.
+// Issues from pages fetched before an error are returned along with it.
+func (p *JiraPlugin) Fetch(ctx context.Context, q Query) ([]contrib.Contribution, error) {
+	p.truncated = false
+	if q.Named != "" {
+		issues, err := p.fetchNamed(ctx, q)
+		return q.finish(issues, p.truncated, wrapError("failed to fetch Jira issues", err))
+	}
+	if p.creditBy == jiraCreditActivity && (len(q.Identities) > 0 || len(q.Targets) == 0) {
+		issues, err := p.fetchCreditedIssues(ctx, q)
+		return q.finish(issues, p.truncated, wrapError("failed to fetch Jira issues", err))
+	}
+
+	issues, err := p.searchIssues(ctx, buildJQL(q, p.jqlLocation(ctx, q.Since, q.Until)), q.Limit, p.searchFields(ctx))
+	return q.finish(issues, p.truncated, wrapError("failed to fetch Jira issues", err))
 }
 
-func (p *JiraPlugin) listIssues(projectKey string) error {
-	endpoint := fmt.Sprintf("/rest/api/2/search?jql=project=\"%s\"", projectKey)
+// buildJQL translates a Query into a JQL search, writing the date range in loc
+func buildJQL(q Query, loc *time.Location) string {
+	var assignees []string
+	for _, id := range q.Identities {
+		switch {
+		case id.Login != "":
+			assignees = append(assignees, jqlQuote(id.Login))
+		case id.Email != "":
+			assignees = append(assignees, jqlQuote(id.Email))
+		}
+	}
 
-	resp, err := p.makeRequest("GET", endpoint, nil, false)
-	if err != nil {
-		return wrapError("failed to fetch Jira issues", err)
+	var clauses []string
+	if len(assignees) > 0 {
+		clauses = append(clauses, fmt.Sprintf("assignee in (%s)", strings.Join(assignees, ", ")))
+	} else if len(q.Targets) == 0 {
+		clauses = append(clauses, "assignee = currentUser()")
 	}
-	defer HandleResponseBody(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to fetch issues, status: %s, response: %s", resp.Status, string(body))
+	if len(q.Targets) > 0 {
+		clauses = append(clauses, jqlProjects(q.Targets))
 	}
+	if !q.Since.IsZero() {
+		clauses = append(clauses, jqlUpdatedSince(q.Since, loc))
+	}
+	if !q.Until.IsZero() {
+		clauses = append(clauses, jqlUpdatedUntil(q.Until, loc))
+	}
+
+	return strings.Join(clauses, " AND ") + " ORDER BY updated DESC"
+}
 
-	var result struct {
-		Issues []struct {
-			Key    string `json:"key"`
-			Fields struct {
-				Summary string `json:"summary"`
-			} `json:"fields"`
-		} `json:"issues"`
+func jqlProjects(projects []string) string {
+	quoted := make([]string, len(projects))
+	for i, project := range projects {
+		quoted[i] = jqlQuote(project)
 	}
+	return fmt.Sprintf("project in (%s)", strings.Join(quoted, ", "))
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to parse response: %v", err)
+func jqlUpdatedSince(since time.Time, loc *time.Location) string {
+	return "updated >= " + jqlTime(since, loc)
+}
+
+// jqlUpdatedUntil bounds updated by the start of the minute after until, as
+// JQL only has minute precision
+func jqlUpdatedUntil(until time.Time, loc *time.Location) string {
+	return "updated < " + jqlTime(until.Truncate(time.Minute).Add(time.Minute), loc)
+}
+
+// jqlTime renders t for a JQL date comparison. Jira reads such dates in the
+// searching user's profile time zone, so t is given in loc, see jqlLocation.
+func jqlTime(t time.Time, loc *time.Location) string {
+	return jqlQuote(t.In(loc).Format("2006-01-02 15:04"))
+}
+
+func jqlQuote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+func (p *JiraPlugin) Info() (string, string) {
+	return "jira", "Integration with Jira for tracking issues"
+}
+
+func (p *JiraPlugin) listIssues(ctx context.Context, projectKey string, limit int) error {
+	issues, err := p.searchIssues(ctx, "project = "+jqlQuote(projectKey), limit, p.searchFields(ctx))
+	if err != nil {
+		return wrapError("failed to fetch Jira issues", err)
 	}
 
 	fmt.Printf("\n📌 Issues for project **%s**:\n", projectKey)
-	for _, issue := range result.Issues {
-		fmt.Printf("   - [%s] %s\n", issue.Key, issue.Fields.Summary)
+	for _, issue := range issues {
+		fmt.Printf("   - [%s] %s\n", issue.ID, issue.Title)
 	}
 	logger.Logger.Info().
 		Str("Project", projectKey).
-		Int("Issue Count", len(result.Issues)).
+		Int("Issue Count", len(issues)).
 		Msg("Fetched Jira issues")
 
 	return nil
 }
 
-func (p *JiraPlugin) assignedIssues(userEmail string) error {
-	jql := fmt.Sprintf("assignee='%s' ORDER BY updated DESC", userEmail)
-	encodedJQL := url.QueryEscape(jql)
-	endpoint := fmt.Sprintf("/rest/api/2/search?jql=%s", encodedJQL)
+func (p *JiraPlugin) assignedIssues(ctx context.Context, userEmail string, r daterange.Range, limit int) error {
+	issues, err := p.fetchAssignedIssues(ctx, userEmail, r, limit, p.searchFields(ctx))
+	if err != nil {
+		return err
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("\n📌 No issues assigned to **%s**.\n", userEmail)
+		return nil
+	}
+
+	fmt.Printf("\n📌 Issues assigned to **%s**:\n", userEmail)
+
+	for _, issue := range issues {
+		fmt.Printf("   - [%s] (%s) %s\n", issue.ID, issue.Metadata["issuetype"], issue.Title)
+		fmt.Printf("     🔹 Status: %s | 📅 Updated: %s\n", issue.Status, issue.UpdatedAt.Format(time.RFC3339))
+	}
 
-	fmt.Println("Final Request URL:", p.baseURL+endpoint)
+	return nil
+}
 
-	resp, err := p.makeRequest("GET", endpoint, nil, false)
+// generateAISummary prints a self-evaluation of issues written by the
+// configured model, summarizing them in groups first if there are too many
+// for one prompt
+func (p *JiraPlugin) generateAISummary(ctx context.Context, userEmail string, r daterange.Range, issues []contrib.Contribution, opts summary.Options) error {
+	provider, err := llm.FromConfig()
 	if err != nil {
-		return wrapError("failed to fetch assigned issues", err)
+		return err
+	}
+	prompts, err := prompt.LoadFromConfig()
+	if err != nil {
+		return err
 	}
-	defer HandleResponseBody(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to fetch assigned issues, status: %s, response: %s", resp.Status, string(body))
+	user := prompt.ProfileFromConfig(&config.ConfigData)
+	if len(user.Emails) == 0 {
+		user.Emails = []string{userEmail}
+	}
+	result, err := summary.New(provider, prompts, opts).Summarize(ctx, issues, r, user)
+	if err != nil {
+		return wrapError("failed to get AI summary", err)
 	}
 
-	var result struct {
-		Issues []struct {
-			Key    string `json:"key"`
-			Fields struct {
-				Summary   string `json:"summary"`
-				IssueType struct {
-					Name string `json:"name"`
-				} `json:"issuetype"`
-				Status struct {
-					Name string `json:"name"`
-				} `json:"status"`
-				Updated string `json:"updated"`
-			} `json:"fields"`
-		} `json:"issues"`
+	fmt.Println("\n📌 AI-Generated Summary:\n" + result.Text)
+	fmt.Println("\nℹ️ " + result.Coverage())
+	if result.Truncated {
+		fmt.Println("⚠️ The summary hit the token limit and may be cut off; raise --max-tokens or llm.max_tokens.")
 	}
+	return nil
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to parse response: %v", err)
+// fetchUserIssues returns the issues userEmail worked on in r, credited as
+// plugins.jira.credit_by says. withText adds descriptions and comments.
+func (p *JiraPlugin) fetchUserIssues(ctx context.Context, userEmail string, r daterange.Range, limit int, withText bool) ([]contrib.Contribution, error) {
+	if p.creditBy == jiraCreditAssignee {
+		fields := p.searchFields(ctx)
+		if withText {
+			fields = p.searchFields(ctx, jiraTextFields...)
+		}
+		return p.fetchAssignedIssues(ctx, userEmail, r, limit, fields)
 	}
 
-	if len(result.Issues) == 0 {
-		fmt.Printf("\n📌 No issues assigned to **%s**.\n", userEmail)
-		return nil
+	q := Query{Identities: []contrib.Identity{{Email: userEmail}}, Since: r.Since, Until: r.Until, Limit: limit}
+	issues, err := p.fetchCreditedIssues(ctx, q)
+	if err != nil {
+		return nil, wrapError("failed to fetch credited issues", err)
 	}
+	return issues, nil
+}
 
-	fmt.Printf("\n📌 Issues assigned to **%s**:\n", userEmail)
+// activity prints the issues userEmail worked on in r and why each one counts
+func (p *JiraPlugin) activity(ctx context.Context, userEmail string, r daterange.Range, limit int) error {
+	q := Query{Identities: []contrib.Identity{{Email: userEmail}}, Since: r.Since, Until: r.Until, Limit: limit}
+	issues, err := p.fetchCreditedIssues(ctx, q)
+	if err != nil {
+		return wrapError("failed to fetch credited issues", err)
+	}
 
-	for _, issue := range result.Issues {
-		fmt.Printf("   - [%s] (%s) %s\n", issue.Key, issue.Fields.IssueType.Name, issue.Fields.Summary)
-		fmt.Printf("     🔹 Status: %s | 📅 Updated: %s\n", issue.Fields.Status.Name, issue.Fields.Updated)
+	if len(issues) == 0 {
+		fmt.Printf("\n📌 No Jira activity by **%s** (%s).\n", userEmail, r)
+		return nil
 	}
 
+	fmt.Printf("\n📌 Jira issues **%s** worked on (%s):\n", userEmail, r)
+	for _, issue := range issues {
+		fmt.Printf("   - [%s] (%s) %s\n", issue.ID, issue.Metadata["issuetype"], issue.Title)
+		fmt.Printf("     🔹 Status: %s | 🏅 Credited: %s\n", issue.Status, strings.Join(issue.MetadataStrings("credit"), ", "))
+	}
 	return nil
 }
 
-func (p *JiraPlugin) generateAISummary(userEmail string, issues []struct {
-	Key       string
-	Summary   string
-	IssueType string
-	Status    string
-	Updated   string
-}) error {
+// fetchAssignedIssues returns up to limit issues assigned to userEmail (all if limit is 0),
+// filtered server-side to those updated in r
+func (p *JiraPlugin) fetchAssignedIssues(ctx context.Context, userEmail string, r daterange.Range, limit int, fields []string) ([]contrib.Contribution, error) {
+	q := Query{Identities: []contrib.Identity{{Email: userEmail}}, Since: r.Since, Until: r.Until}
+	issues, err := p.searchIssues(ctx, buildJQL(q, p.jqlLocation(ctx, r.Since, r.Until)), limit, fields)
+	if err != nil {
+		return nil, wrapError("failed to fetch assigned issues", err)
+	}
+	return issues, nil
+}
+
+// jiraUser is the user object Jira embeds in assignee/reporter fields
+type jiraUser struct {
+	AccountID    string `json:"accountId"`
+	Name         string `json:"name"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	TimeZone     string `json:"timeZone"` // IANA zone of the user's profile, e.g. Europe/Berlin
+}
 
-	apiKey := os.Getenv("OPENAI_API_KEY")
-	os.Getenv("OPENAI_ORG")
+// jiraIssue is the subset of the Jira issue payload csync understands
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Status struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"` // new, indeterminate or done
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+		Parent      *jiraParent `json:"parent"`
+		FixVersions []struct {
+			Name string `json:"name"`
+		} `json:"fixVersions"`
+		Assignee       *jiraUser `json:"assignee"`
+		Reporter       *jiraUser `json:"reporter"`
+		Created        string    `json:"created"`
+		Updated        string    `json:"updated"`
+		ResolutionDate string    `json:"resolutiondate"`
+		// Description and comment bodies are strings in v2 and ADF documents in v3
+		Description json.RawMessage `json:"description"`
+		Comment     struct {
+			Comments []jiraComment `json:"comments"`
+		} `json:"comment"`
+		Worklog struct {
+			Total    int           `json:"total"` // More than len(Worklogs) when only the first page is embedded
+			Worklogs []jiraWorklog `json:"worklogs"`
+		} `json:"worklog"`
+	} `json:"fields"`
+	Changelog struct {
+		Total     int           `json:"total"`
+		Histories []jiraHistory `json:"histories"`
+	} `json:"changelog"`
+	// custom holds the customfield_* values, whose IDs differ between sites
+	custom map[string]json.RawMessage
+}
 
-	if apiKey == "" {
-		return fmt.Errorf("OPENAI_API_KEY is not set. Please export your API key.")
+func (i *jiraIssue) UnmarshalJSON(data []byte) error {
+	type plain jiraIssue // Without this method, to avoid recursing
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
 	}
 
-	var formattedIssuesText strings.Builder
-	for _, issue := range issues {
-		formattedIssuesText.WriteString(fmt.Sprintf("- [%s] %s: %s (Status: %s, Updated: %s)\n",
-			issue.IssueType, issue.Key, issue.Summary, issue.Status, issue.Updated))
+	var raw struct {
+		Fields map[string]json.RawMessage `json:"fields"`
 	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for id, value := range raw.Fields {
+		if strings.HasPrefix(id, "customfield_") {
+			if i.custom == nil {
+				i.custom = make(map[string]json.RawMessage)
+			}
+			i.custom[id] = value
+		}
+	}
+	return nil
+}
 
-	prompt := fmt.Sprintf(`
-      I am preparing a self-evaluation for my work. Please summarize my Jira contributions in a professional yet concise way.
-      Focus on the impact of my work rather than just listing tasks. 
-      Frame the summary as if I am personally describing my achievements for a performance review.
+type jiraComment struct {
+	Author  *jiraUser       `json:"author"`
+	Body    json.RawMessage `json:"body"`
+	Created string          `json:"created"`
+}
+
+// jiraSearchFields are the issue fields csync reads; requesting only these
+// keeps search responses small compared to Jira's default of every field
+var jiraSearchFields = []string{
+	"summary", "issuetype", "status", "project", "assignee", "reporter", "created", "updated", "resolutiondate",
+	"parent", "fixVersions",
+}
 
-      Here are my recent Jira contributions:
-      %s
+// jiraTextFields are the long rich-text fields, requested only by callers
+// that read them: show, summary and activity crediting
+var jiraTextFields = []string{"description", "comment"}
 
-      Respond in the first person, starting with "I...".
-      Use natural language that sounds like something I would say in a self-assessment.
-      Keep it concise and focused on impact.`, formattedIssuesText.String())
+// jiraPageSize is the maxResults requested per search page. Jira may return
+// fewer per page than asked, so paging follows the response rather than this.
+const jiraPageSize = 100
 
-	payload := map[string]interface{}{
-		"model": "gpt-3.5-turbo",
-		"messages": []map[string]string{
-			{"role": "system", "content": "You are an assistant summarizing Jira issue contributions."},
-			{"role": "user", "content": prompt},
-		},
-		"max_tokens": 200,
+// searchIssues runs a JQL query for fields, paging through every result, and
+// converts the matching issues into contributions. A positive limit stops
+// after that many issues and reports that the results were cut short.
+func (p *JiraPlugin) searchIssues(ctx context.Context, jql string, limit int, fields []string) ([]contrib.Contribution, error) {
+	issues, err := p.searchRaw(ctx, jql, limit, fields, "")
+	contributions := make([]contrib.Contribution, len(issues))
+	for i, issue := range issues {
+		contributions[i] = p.issueContribution(issue)
 	}
+	return contributions, err
+}
 
-	body, err := toJSON(payload)
-	if err != nil {
-		return wrapError("failed to prepare AI request payload", err)
+// searchRaw pages through a JQL query's issues, requesting fields and, if set,
+// the expand option (e.g. "changelog"). A positive limit stops after that many
+// issues, setting p.truncated if more match.
+func (p *JiraPlugin) searchRaw(ctx context.Context, jql string, limit int, fields []string, expand string) ([]jiraIssue, error) {
+	var issues []jiraIssue
+	_, err := p.searchEach(ctx, jql, limit, fields, expand, func(issue jiraIssue) (bool, error) {
+		issues = append(issues, issue)
+		return true, nil
+	})
+	return issues, err
+}
+
+// searchEach pages through a JQL query as searchRaw does, passing each issue to
+// visit and only fetching the next page once visit has seen the current one.
+// It stops early when visit returns false or an error, and reports whether
+// that left matching issues unvisited.
+func (p *JiraPlugin) searchEach(ctx context.Context, jql string, limit int, fields []string, expand string, visit func(jiraIssue) (bool, error)) (bool, error) {
+	read := 0
+	var cursor jiraSearchCursor
+	for {
+		pageSize := jiraPageSize
+		if limit > 0 {
+			pageSize = min(pageSize, limit-read)
+		}
+
+		page, err := p.searchPage(ctx, jql, cursor, pageSize, fields, expand)
+		if err != nil {
+			return false, err
+		}
+		read += len(page.Issues)
+		cursor = jiraSearchCursor{startAt: cursor.startAt + len(page.Issues), token: page.NextPageToken}
+		last := len(page.Issues) == 0 || page.last(cursor.startAt)
+
+		for i, issue := range page.Issues {
+			more, err := visit(issue)
+			if err != nil {
+				return false, err
+			}
+			if !more {
+				return i < len(page.Issues)-1 || !last, nil
+			}
+		}
+
+		if last {
+			return false, nil
+		}
+		if limit > 0 && read >= limit {
+			p.truncated = true
+			logger.Logger.Warn().Int("limit", limit).Int("total", page.Total).Msg("Jira search truncated")
+			if page.Total > 0 {
+				fmt.Printf("⚠️ %d issues match; only the first %d are included. Raise --limit to see more.\n", page.Total, limit)
+			} else {
+				fmt.Printf("⚠️ More than %d issues match; only the first %d are included. Raise --limit to see more.\n", limit, limit)
+			}
+			return true, nil
+		}
 	}
+}
+
+// jiraSearchResult is one page of /rest/api/{2,3}/search (Server and Data
+// Center, paged by startAt/total) or of /rest/api/{2,3}/search/jql (Cloud,
+// paged by nextPageToken, with no total)
+type jiraSearchResult struct {
+	StartAt       int         `json:"startAt"`
+	MaxResults    int         `json:"maxResults"`
+	Total         int         `json:"total"`
+	NextPageToken string      `json:"nextPageToken"`
+	IsLast        bool        `json:"isLast"`
+	Issues        []jiraIssue `json:"issues"`
+}
 
-	resp, err := p.makeRequest("POST", "https://api.openai.com/v1/chat/completions", bytes.NewReader(body), true)
+// last reports whether this is the final page, read being the number of issues read so far
+func (r *jiraSearchResult) last(read int) bool {
+	return r.IsLast || (r.NextPageToken == "" && read >= r.Total)
+}
+
+// jiraSearchCursor is where the next search page starts: an offset on Server
+// and Data Center, the previous page's nextPageToken on Cloud
+type jiraSearchCursor struct {
+	startAt int
+	token   string
+}
+
+// searchPage fetches one page of a JQL search. Cloud has removed the
+// startAt-paged search endpoint, so it is searched via search/jql instead.
+func (p *JiraPlugin) searchPage(ctx context.Context, jql string, cursor jiraSearchCursor, maxResults int, fields []string, expand string) (*jiraSearchResult, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	params.Set("fields", strings.Join(fields, ","))
+	if expand != "" {
+		params.Set("expand", expand)
+	}
+
+	resource := "search"
+	if p.isCloud() {
+		resource = "search/jql"
+		if cursor.token != "" {
+			params.Set("nextPageToken", cursor.token)
+		}
+	} else {
+		params.Set("startAt", strconv.Itoa(cursor.startAt))
+	}
+	endpoint := p.restPath(resource) + "?" + params.Encode()
+	logger.Logger.Debug().Str("url", p.apiURL()+endpoint).Msg("Searching Jira issues")
+
+	resp, err := p.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return wrapError("failed to get AI summary", err)
+		return nil, err
 	}
 	defer HandleResponseBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get AI summary, status: %s, response: %s", resp.Status, string(respBody))
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("status: %s, response: %s", resp.Status, string(body))
+	}
+
+	var result jiraSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
 	}
+	return &result, nil
+}
 
-	var result struct {
-		Choices []struct {
-			Message struct {
-				Content string `json:"content"`
-			} `json:"message"`
-		} `json:"choices"`
+func (p *JiraPlugin) issueContribution(issue jiraIssue) contrib.Contribution {
+	var authors []contrib.Identity
+	if issue.Fields.Assignee != nil {
+		authors = append(authors, issue.Fields.Assignee.identity())
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to parse AI response: %v", err)
+	metadata := map[string]any{"issuetype": issue.Fields.IssueType.Name}
+	if issue.Fields.Reporter != nil {
+		metadata["reporter"] = issue.Fields.Reporter.identity()
+	}
+	if description := renderJiraText(issue.Fields.Description); description != "" {
+		metadata["description"] = description
+	}
+	p.addAgileMetadata(issue, metadata)
+	if comments := issue.Fields.Comment.Comments; len(comments) > 0 {
+		rendered := make([]map[string]string, len(comments))
+		for i, comment := range comments {
+			var author string
+			if comment.Author != nil {
+				author = comment.Author.DisplayName
+			}
+			rendered[i] = map[string]string{"author": author, "created": comment.Created, "body": renderJiraText(comment.Body)}
+		}
+		metadata["comments"] = rendered
+	}
+
+	return contrib.Contribution{
+		Source:    contrib.SourceJira,
+		Kind:      contrib.KindIssue,
+		ID:        issue.Key,
+		Project:   issue.Fields.Project.Key,
+		Title:     issue.Fields.Summary,
+		URL:       p.issueURL(issue.Key),
+		Authors:   authors,
+		Status:    issue.Fields.Status.Name,
+		CreatedAt: parseJiraTime(issue.Fields.Created),
+		UpdatedAt: parseJiraTime(issue.Fields.Updated),
+		ClosedAt:  parseJiraTime(issue.Fields.ResolutionDate),
+		Metadata:  metadata,
 	}
+}
 
-	if len(result.Choices) > 0 {
-		fmt.Println("\n📌 AI-Generated Summary:\n" + result.Choices[0].Message.Content)
-	} else {
-		fmt.Println("\n⚠️ AI did not return a summary.")
+func (u *jiraUser) identity() contrib.Identity {
+	login := u.AccountID
+	if login == "" {
+		login = u.Name // Server/Data Center has no account IDs
 	}
+	return contrib.Identity{Name: u.DisplayName, Email: u.EmailAddress, Login: login}
+}
 
-	return nil
+// parseJiraTime parses Jira's timestamp format, returning the zero time if it is empty or malformed
+func parseJiraTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", value)
+	if err != nil {
+		logger.Logger.Debug().Str("value", value).Err(err).Msg("Unrecognised Jira timestamp")
+		return time.Time{}
+	}
+	return t
+}
+
+// issueURL is the browser link for an issue
+func (p *JiraPlugin) issueURL(key string) string {
+	return strings.TrimSuffix(p.baseURL, "/") + "/browse/" + key
+}
+
+// restPath returns the path of a REST resource in the configured API version, e.g. /rest/api/3/search
+func (p *JiraPlugin) restPath(resource string) string {
+	return fmt.Sprintf("/rest/api/%d/%s", p.apiVersion, resource)
 }
 
-func (p *JiraPlugin) fetchAssignedIssues(userEmail string) ([]struct {
-	Key       string
-	Summary   string
-	IssueType string
-	Status    string
-	Updated   string
-}, error) {
-	jql := fmt.Sprintf("assignee='%s' ORDER BY updated DESC", userEmail)
-	encodedJQL := url.QueryEscape(jql)
-	endpoint := fmt.Sprintf("/rest/api/2/search?jql=%s", encodedJQL)
+// richText prepares Markdown for a rich-text field: an ADF document in v3, the string itself in v2
+func (p *JiraPlugin) richText(markdown string) any {
+	if p.apiVersion == 3 {
+		return adf.FromMarkdown(markdown)
+	}
+	return markdown
+}
+
+// renderJiraText turns a description or comment body into Markdown, whether
+// Jira sent a plain string (v2) or an ADF document (v3)
+func renderJiraText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return strings.TrimSpace(text)
+	}
+
+	doc, err := adf.Parse(raw)
+	if err != nil {
+		logger.Logger.Debug().Err(err).Msg("Unrecognised Jira rich text")
+		return ""
+	}
+	return adf.Markdown(doc)
+}
 
-	resp, err := p.makeRequest("GET", endpoint, nil, false)
+// showIssue prints an issue with its description and comments
+func (p *JiraPlugin) showIssue(ctx context.Context, key string) error {
+	endpoint := p.restPath("issue/"+url.PathEscape(key)) + "?fields=" + strings.Join(p.searchFields(ctx, jiraTextFields...), ",")
+	resp, err := p.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
-		return nil, wrapError("failed to fetch assigned issues", err)
+		return wrapError("failed to fetch Jira issue", err)
 	}
 	defer HandleResponseBody(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to fetch assigned issues, status: %s, response: %s", resp.Status, string(body))
+		return fmt.Errorf("failed to fetch Jira issue, status: %s, response: %s", resp.Status, string(body))
 	}
 
-	var result struct {
-		Issues []struct {
-			Key    string `json:"key"`
-			Fields struct {
-				Summary   string `json:"summary"`
-				IssueType struct {
-					Name string `json:"name"`
-				} `json:"issuetype"`
-				Status struct {
-					Name string `json:"name"`
-				} `json:"status"`
-				Updated string `json:"updated"`
-			} `json:"fields"`
-		} `json:"issues"`
+	var issue jiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return fmt.Errorf("failed to parse response: %v", err)
 	}
+	c := p.issueContribution(issue)
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %v", err)
+	fmt.Printf("\n📌 [%s] %s\n", c.ID, c.Title)
+	fmt.Printf("   🔹 Type: %s | Status: %s | 📅 Updated: %s\n", c.Metadata["issuetype"], c.Status, c.UpdatedAt.Format(time.RFC3339))
+	if agile := formatAgile(c); agile != "" {
+		fmt.Printf("   🏃 %s\n", agile)
 	}
-
-	formattedIssues := make([]struct {
-		Key       string
-		Summary   string
-		IssueType string
-		Status    string
-		Updated   string
-	}, len(result.Issues))
-
-	for i, issue := range result.Issues {
-		formattedIssues[i] = struct {
-			Key       string
-			Summary   string
-			IssueType string
-			Status    string
-			Updated   string
-		}{
-			Key:       issue.Key,
-			Summary:   issue.Fields.Summary,
-			IssueType: issue.Fields.IssueType.Name,
-			Status:    issue.Fields.Status.Name,
-			Updated:   issue.Fields.Updated,
+	fmt.Printf("   🔗 %s\n", c.URL)
+	if description, ok := c.Metadata["description"].(string); ok {
+		fmt.Printf("\n%s\n", description)
+	}
+	if comments, ok := c.Metadata["comments"].([]map[string]string); ok {
+		fmt.Printf("\n💬 Comments (%d):\n", len(comments))
+		for _, comment := range comments {
+			fmt.Printf("\n— %s, %s\n%s\n", comment["author"], comment["created"], comment["body"])
 		}
 	}
-
-	return formattedIssues, nil
+	return nil
 }
@@ -0,0 +1,304 @@
+package plugins
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/report"
+)
+
+// sprintFieldType is the schema of Jira Software's Sprint field
+const sprintFieldType = "com.pyxis.greenhopper.jira:gh-sprint"
+
+// storyPointsNames are tried in order when plugins.jira.story_points_field is
+// empty: company-managed projects use the first, team-managed the second
+var storyPointsNames = []string{"Story Points", "Story point estimate"}
+
+// jiraAgileFields are the IDs of the Jira Software custom fields on this
+// site. Any of them is empty when the site doesn't have it.
+type jiraAgileFields struct {
+	sprint      string
+	epicLink    string
+	storyPoints string
+}
+
+func (f *jiraAgileFields) ids() []string {
+	var ids []string
+	for _, id := range []string{f.sprint, f.epicLink, f.storyPoints} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// jiraParent is an issue's parent: the epic for standard issues on
+// team-managed projects (and on Cloud generally), the story for sub-tasks
+type jiraParent struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+	} `json:"fields"`
+}
+
+type jiraSprint struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	State        string `json:"state"`
+	EndDate      string `json:"endDate"`
+	CompleteDate string `json:"completeDate"`
+}
+
+// end is when the sprint finished, or is due to
+func (s jiraSprint) end() time.Time {
+	for _, value := range []string{s.CompleteDate, s.EndDate} {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+		if t := parseJiraTime(value); !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// searchFields is jiraSearchFields plus extra and the site's sprint, epic link and story points fields
+func (p *JiraPlugin) searchFields(ctx context.Context, extra ...string) []string {
+	return slices.Concat(jiraSearchFields, extra, p.agileFields(ctx).ids())
+}
+
+// agileFields finds the Jira Software custom fields once per run. Sites
+// without them, or without permission to list fields, just go without.
+func (p *JiraPlugin) agileFields(ctx context.Context) *jiraAgileFields {
+	if p.agile != nil {
+		return p.agile
+	}
+	p.agile = &jiraAgileFields{}
+
+	fields, err := p.loadFields(ctx)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("Could not look up Jira fields; sprint, epic and story points are left out")
+		return p.agile
+	}
+	for _, field := range fields {
+		switch field.Schema.Custom {
+		case sprintFieldType:
+			p.agile.sprint = field.ID
+		case epicLinkType:
+			p.agile.epicLink = field.ID
+		}
+	}
+
+	names := storyPointsNames
+	if p.storyPoints != "" {
+		names = []string{p.storyPoints}
+	}
+	for _, name := range names {
+		if field, err := p.lookupField(ctx, name); err == nil {
+			p.agile.storyPoints = field.ID
+			break
+		}
+	}
+	if p.agile.storyPoints == "" && p.storyPoints != "" {
+		logger.Logger.Warn().Str("field", p.storyPoints).Msg("Story points field not found; check plugins.jira.story_points_field")
+	}
+	return p.agile
+}
+
+// addAgileMetadata records an issue's epic, sprints, story points and fix versions
+func (p *JiraPlugin) addAgileMetadata(issue jiraIssue, metadata map[string]any) {
+	agile := p.agile
+	if agile == nil {
+		agile = &jiraAgileFields{}
+	}
+
+	if parent := issue.Fields.Parent; parent != nil {
+		if strings.EqualFold(parent.Fields.IssueType.Name, "Epic") {
+			metadata["epic"] = parent.Key
+			metadata["epic_title"] = parent.Fields.Summary
+		} else {
+			metadata["parent"] = parent.Key
+		}
+	}
+	if raw, ok := issue.custom[agile.epicLink]; ok {
+		var key string
+		if json.Unmarshal(raw, &key) == nil && key != "" {
+			metadata["epic"] = key
+		}
+	}
+
+	if sprints := parseSprints(issue.custom[agile.sprint]); len(sprints) > 0 {
+		names := make([]string, len(sprints))
+		for i, sprint := range sprints {
+			names[i] = sprint.Name
+		}
+		metadata["sprints"] = names
+		if end := sprints[len(sprints)-1].end(); !end.IsZero() {
+			metadata["sprint_end"] = end.Format(time.RFC3339)
+		}
+	}
+
+	if raw, ok := issue.custom[agile.storyPoints]; ok {
+		var points float64
+		if json.Unmarshal(raw, &points) == nil && points != 0 {
+			metadata["story_points"] = points
+		}
+	}
+
+	if versions := issue.Fields.FixVersions; len(versions) > 0 {
+		names := make([]string, len(versions))
+		for i, version := range versions {
+			names[i] = version.Name
+		}
+		metadata["fix_versions"] = names
+	}
+}
+
+// serverSprintKey finds the keys in Server/Data Center's sprint strings, e.g.
+// "com.atlassian.greenhopper.service.sprint.Sprint@1f[id=3,rapidViewId=1,state=CLOSED,name=Sprint 3,...]"
+var serverSprintKey = regexp.MustCompile(`(?:^|,)(\w+)=`)
+
+// parseSprints reads the Sprint field, oldest sprint first. Cloud sends
+// objects; Server and Data Center send strings of key=value pairs.
+func parseSprints(raw json.RawMessage) []jiraSprint {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	var objects []jiraSprint
+	if err := json.Unmarshal(raw, &objects); err == nil {
+		return sortSprints(objects)
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil {
+		logger.Logger.Debug().Err(err).Msg("Unrecognised Jira sprint field")
+		return nil
+	}
+	var sprints []jiraSprint
+	for _, value := range values {
+		start, end := strings.Index(value, "["), strings.LastIndex(value, "]")
+		if start < 0 || end < start {
+			continue
+		}
+		body := value[start+1 : end]
+
+		pairs := make(map[string]string)
+		keys := serverSprintKey.FindAllStringSubmatchIndex(body, -1)
+		for i, loc := range keys {
+			valueEnd := len(body)
+			if i+1 < len(keys) {
+				valueEnd = keys[i+1][0]
+			}
+			value := body[loc[1]:valueEnd]
+			if value == "<null>" {
+				value = ""
+			}
+			pairs[body[loc[2]:loc[3]]] = value
+		}
+		id, _ := strconv.Atoi(pairs["id"])
+		sprints = append(sprints, jiraSprint{
+			ID:           id,
+			Name:         pairs["name"],
+			State:        pairs["state"],
+			EndDate:      pairs["endDate"],
+			CompleteDate: pairs["completeDate"],
+		})
+	}
+	return sortSprints(sprints)
+}
+
+// sortSprints orders sprints by when they ended, then by ID, with undated
+// sprints last
+func sortSprints(sprints []jiraSprint) []jiraSprint {
+	slices.SortFunc(sprints, func(a, b jiraSprint) int {
+		aEnd, bEnd := a.end(), b.end()
+		switch {
+		case aEnd.IsZero() != bEnd.IsZero():
+			if aEnd.IsZero() {
+				return 1
+			}
+			return -1
+		case !aEnd.Equal(bEnd):
+			return aEnd.Compare(bEnd)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return sprints
+}
+
+// formatAgile summarises an issue's epic, sprint, points and versions on one line
+func formatAgile(c contrib.Contribution) string {
+	var parts []string
+	if epic, _ := c.Metadata["epic"].(string); epic != "" {
+		if title, _ := c.Metadata["epic_title"].(string); title != "" {
+			epic += " " + title
+		}
+		parts = append(parts, "Epic: "+epic)
+	}
+	if sprints := c.MetadataStrings("sprints"); len(sprints) > 0 {
+		parts = append(parts, "Sprint: "+strings.Join(sprints, ", "))
+	}
+	if points := report.StoryPoints(c); points != 0 {
+		parts = append(parts, "Points: "+formatPoints(points))
+	}
+	if versions := c.MetadataStrings("fix_versions"); len(versions) > 0 {
+		parts = append(parts, "Fix Version: "+strings.Join(versions, ", "))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(points, 'f', -1, 64)
+}
+
+// rollup prints the issues userEmail completed in r grouped by epic, sprint and month
+func (p *JiraPlugin) rollup(ctx context.Context, userEmail string, r daterange.Range, limit int) error {
+	issues, err := p.fetchUserIssues(ctx, userEmail, r, limit, false)
+	if err != nil {
+		return err
+	}
+
+	rollup := report.BuildRollup(r, issues)
+	if rollup.Issues == 0 {
+		fmt.Printf("\n📦 No completed Jira issues for **%s** (%s).\n", userEmail, r)
+		return nil
+	}
+
+	fmt.Printf("\n📦 Jira work **%s** completed (%s):\n", userEmail, r)
+	printRollupGroups("🧩 By epic", rollup.Epics)
+	printRollupGroups("🏃 By sprint", rollup.Sprints)
+	printRollupGroups("📅 By month", rollup.Periods)
+	fmt.Printf("\n🔢 %s\n", rollup.Summary())
+	return nil
+}
+
+func printRollupGroups(heading string, groups []report.RollupGroup) {
+	fmt.Printf("\n%s:\n", heading)
+	for _, g := range groups {
+		line := g.Key
+		if g.Title != "" {
+			line += " " + g.Title
+		}
+		if !g.End.IsZero() {
+			line += fmt.Sprintf(" (ended %s)", g.End.Local().Format(time.DateOnly))
+		}
+		fmt.Printf("   - %s: %d issues, %s points\n", line, len(g.Items), formatPoints(g.Points))
+		for _, item := range g.Items {
+			fmt.Printf("       [%s] %s\n", item.ID, item.Title)
+		}
+	}
+}
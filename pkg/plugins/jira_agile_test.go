@@ -0,0 +1,42 @@
+package plugins
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestParseSprints(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{
+			name: "cloud objects",
+			raw: `[{"id":9,"name":"Future","state":"future"},
+				{"id":4,"name":"Sprint 4","state":"closed","endDate":"2026-08-14T00:00:00.000Z","completeDate":"2026-08-15T09:00:00.000Z"},
+				{"id":8,"name":"Also future","state":"future"},
+				{"id":3,"name":"Sprint 3","state":"closed","endDate":"2026-07-31T00:00:00.000Z"},
+				{"id":2,"name":"Sprint 3b","state":"closed","endDate":"2026-07-31T00:00:00.000Z"}]`,
+			want: []string{"Sprint 3b", "Sprint 3", "Sprint 4", "Also future", "Future"},
+		},
+		{
+			name: "server strings",
+			raw: `["com.atlassian.greenhopper.service.sprint.Sprint@1f[id=5,rapidViewId=1,state=ACTIVE,name=Sprint 5,startDate=<null>,endDate=<null>,completeDate=<null>]",
+				"com.atlassian.greenhopper.service.sprint.Sprint@2a[id=4,rapidViewId=1,state=CLOSED,name=Sprint 4,endDate=2026-08-14T00:00:00.000Z,completeDate=<null>]"]`,
+			want: []string{"Sprint 4", "Sprint 5"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for _, sprint := range parseSprints(json.RawMessage(tc.raw)) {
+				names = append(names, sprint.Name)
+			}
+			if !slices.Equal(names, tc.want) {
+				t.Errorf("sprints = %q, want %q", names, tc.want)
+			}
+		})
+	}
+}
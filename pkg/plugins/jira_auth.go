@@ -0,0 +1,91 @@
+package plugins
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Jira authentication modes, chosen with plugins.jira.auth
+const (
+	jiraAuthBasic = "basic" // Jira Cloud: JIRA_EMAIL + JIRA_API_TOKEN
+	jiraAuthPAT   = "pat"   // Server/Data Center Personal Access Token in JIRA_API_TOKEN
+	jiraAuthOAuth = "oauth" // OAuth 2.0 (3LO) access token in JIRA_OAUTH_TOKEN
+)
+
+// atlassianAPIURL is where OAuth 2.0 apps reach a Cloud site's REST API
+const atlassianAPIURL = "https://api.atlassian.com/ex/jira/"
+
+// loadCredentials reads the environment variables the configured auth mode needs
+func (p *JiraPlugin) loadCredentials() error {
+	switch p.auth {
+	case "", jiraAuthBasic:
+		p.auth = jiraAuthBasic
+		p.email = os.Getenv("JIRA_EMAIL")
+		p.apiToken = os.Getenv("JIRA_API_TOKEN")
+		if p.email == "" || p.apiToken == "" {
+			return fmt.Errorf("basic auth needs JIRA_EMAIL and JIRA_API_TOKEN")
+		}
+	case jiraAuthPAT:
+		p.apiToken = os.Getenv("JIRA_API_TOKEN")
+		if p.apiToken == "" {
+			return fmt.Errorf("pat auth needs a Personal Access Token in JIRA_API_TOKEN")
+		}
+	case jiraAuthOAuth:
+		p.apiToken = os.Getenv("JIRA_OAUTH_TOKEN")
+		if p.apiToken == "" {
+			return fmt.Errorf("oauth auth needs an access token in JIRA_OAUTH_TOKEN")
+		}
+		if p.cloudID == "" {
+			return fmt.Errorf("oauth auth needs plugins.jira.cloud_id")
+		}
+	default:
+		return fmt.Errorf("unknown plugins.jira.auth %q (expected basic, pat or oauth)", p.auth)
+	}
+	return nil
+}
+
+// apiURL is the root REST endpoints are resolved against. OAuth apps must go
+// through the Atlassian API gateway; other modes talk to the site directly.
+func (p *JiraPlugin) apiURL() string {
+	if p.auth == jiraAuthOAuth {
+		return atlassianAPIURL + p.cloudID
+	}
+	return strings.TrimSuffix(p.baseURL, "/")
+}
+
+// authorize sets the Authorization header for the configured auth mode
+func (p *JiraPlugin) authorize(req *http.Request) {
+	switch p.auth {
+	case jiraAuthPAT, jiraAuthOAuth:
+		req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	default:
+		credentials := base64.StdEncoding.EncodeToString([]byte(p.email + ":" + p.apiToken))
+		req.Header.Set("Authorization", "Basic "+credentials)
+	}
+}
+
+// credentialsError explains a 401, or a 403 Jira attributes to authentication,
+// in terms of the settings the user has to fix. It returns nil for other responses.
+func (p *JiraPlugin) credentialsError(resp *http.Response) error {
+	reason := resp.Header.Get("X-Authentication-Denied-Reason")
+	if resp.StatusCode != http.StatusUnauthorized && (resp.StatusCode != http.StatusForbidden || reason == "") {
+		return nil
+	}
+
+	var hint string
+	switch p.auth {
+	case jiraAuthPAT:
+		hint = "check that JIRA_API_TOKEN is a valid, unexpired Personal Access Token"
+	case jiraAuthOAuth:
+		hint = "check that JIRA_OAUTH_TOKEN has not expired and plugins.jira.cloud_id is right"
+	default:
+		hint = "check JIRA_EMAIL and JIRA_API_TOKEN (an API token from id.atlassian.com, not your password)"
+	}
+	if reason != "" {
+		hint += "; Jira says: " + reason
+	}
+	return fmt.Errorf("Jira rejected the credentials (%s, auth mode %s): %s", resp.Status, p.auth, hint)
+}
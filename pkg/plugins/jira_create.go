@@ -0,0 +1,646 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"gopkg.in/yaml.v3"
+)
+
+const jiraCreateUsage = "usage: create-issue <projectKey> <summary> [description] [--type T] [--priority P] [--label L]... [--component C]... [--assignee USER] [--parent KEY] [--epic KEY] [--field NAME=VALUE]... [--template FILE] [--dry-run]"
+
+const jiraBulkCreateUsage = "usage: bulk-create <issues.csv|issues.yaml> [--project KEY] [--template FILE] [--dry-run]"
+
+// epicLinkType is the schema of the "Epic Link" field on company-managed
+// projects; team-managed projects and newer Cloud sites use parent instead
+const epicLinkType = "com.pyxis.greenhopper.jira:gh-epic-link"
+
+// jiraIssueSpec describes an issue to create. It is what templates, bulk
+// files and the create-issue flags are read into.
+type jiraIssueSpec struct {
+	Project     string            `yaml:"project"`
+	Type        string            `yaml:"type"`
+	Summary     string            `yaml:"summary"`
+	Description string            `yaml:"description"`
+	Priority    string            `yaml:"priority"`
+	Labels      []string          `yaml:"labels"`
+	Components  []string          `yaml:"components"`
+	Assignee    string            `yaml:"assignee"` // Email, account ID (Cloud) or username (Server/Data Center)
+	Parent      string            `yaml:"parent"`
+	Epic        string            `yaml:"epic"`
+	Fields      map[string]string `yaml:"fields"` // Other fields by name or ID; list values are comma-separated
+}
+
+// withDefaults fills the spec's empty fields from defaults, typically a template
+func (s jiraIssueSpec) withDefaults(defaults jiraIssueSpec) jiraIssueSpec {
+	fill := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	fill(&s.Project, defaults.Project)
+	fill(&s.Type, defaults.Type)
+	fill(&s.Summary, defaults.Summary)
+	fill(&s.Description, defaults.Description)
+	fill(&s.Priority, defaults.Priority)
+	fill(&s.Assignee, defaults.Assignee)
+	fill(&s.Parent, defaults.Parent)
+	fill(&s.Epic, defaults.Epic)
+	if len(s.Labels) == 0 {
+		s.Labels = defaults.Labels
+	}
+	if len(s.Components) == 0 {
+		s.Components = defaults.Components
+	}
+
+	fields := make(map[string]string, len(defaults.Fields)+len(s.Fields))
+	for name, value := range defaults.Fields {
+		fields[name] = value
+	}
+	for name, value := range s.Fields {
+		fields[name] = value
+	}
+	s.Fields = fields
+	return s
+}
+
+func (s jiraIssueSpec) validate() error {
+	switch {
+	case s.Project == "":
+		return errors.New("missing project")
+	case s.Summary == "":
+		return errors.New("missing summary")
+	}
+	return nil
+}
+
+// describe is the one-line preview shown by --dry-run
+func (s jiraIssueSpec) describe() string {
+	issueType := s.Type
+	if issueType == "" {
+		issueType = "Task"
+	}
+	line := fmt.Sprintf("[%s] (%s) %s", s.Project, issueType, s.Summary)
+
+	var details []string
+	add := func(label, value string) {
+		if value != "" {
+			details = append(details, label+" "+value)
+		}
+	}
+	add("priority", s.Priority)
+	add("labels", strings.Join(s.Labels, ", "))
+	add("components", strings.Join(s.Components, ", "))
+	add("assignee", s.Assignee)
+	add("parent", s.Parent)
+	add("epic", s.Epic)
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, s.Fields[name])
+	}
+	if len(details) > 0 {
+		line += " · " + strings.Join(details, " · ")
+	}
+	return line
+}
+
+// jiraField is a field definition from /rest/api/2/field
+type jiraField struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Custom bool   `json:"custom"`
+	Schema struct {
+		Type   string `json:"type"`
+		Items  string `json:"items"`
+		Custom string `json:"custom"`
+	} `json:"schema"`
+}
+
+func (p *JiraPlugin) executeCreateIssue(ctx context.Context, args []string) error {
+	fs := newFlagSet("jira create-issue")
+	var spec jiraIssueSpec
+	fs.StringVar(&spec.Type, "type", "", "Issue type (default Task)")
+	fs.StringVar(&spec.Priority, "priority", "", "Priority name")
+	fs.StringArrayVar(&spec.Labels, "label", nil, "Label to add (repeatable)")
+	fs.StringArrayVar(&spec.Components, "component", nil, "Component name (repeatable)")
+	fs.StringVar(&spec.Assignee, "assignee", "", "Assignee email, account ID or username")
+	fs.StringVar(&spec.Parent, "parent", "", "Parent issue key, for sub-tasks")
+	fs.StringVar(&spec.Epic, "epic", "", "Epic issue key")
+	fields := fs.StringArray("field", nil, "Other field as NAME=VALUE, by name or ID (repeatable)")
+	template := fs.String("template", "", "YAML issue template to take defaults from")
+	dryRun := fs.Bool("dry-run", false, "Print the issue instead of creating it")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%s", err, jiraCreateUsage)
+	}
+	if fs.NArg() < 2 {
+		return errors.New(jiraCreateUsage)
+	}
+	spec.Project, spec.Summary = fs.Arg(0), fs.Arg(1)
+	spec.Description = strings.Join(fs.Args()[2:], " ")
+
+	spec.Fields = make(map[string]string)
+	for _, field := range *fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid --field %q (expected NAME=VALUE)", field)
+		}
+		spec.Fields[strings.TrimSpace(name)] = value
+	}
+
+	if *template != "" {
+		defaults, err := loadIssueTemplate(*template)
+		if err != nil {
+			return err
+		}
+		spec = spec.withDefaults(defaults)
+	}
+
+	payload, err := p.issuePayload(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		body, _ := json.MarshalIndent(payload, "", "  ")
+		fmt.Printf("🔍 Dry run, not creating:\n   %s\n%s\n", spec.describe(), body)
+		return nil
+	}
+
+	key, err := p.createIssue(ctx, payload)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Created %s: %s\n   %s\n", key, spec.Summary, p.issueURL(key))
+	return nil
+}
+
+func (p *JiraPlugin) executeBulkCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("jira bulk-create")
+	project := fs.String("project", "", "Project key for rows that don't name one")
+	template := fs.String("template", "", "YAML issue template to take defaults from")
+	dryRun := fs.Bool("dry-run", false, "Preview the issues instead of creating them")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%s", err, jiraBulkCreateUsage)
+	}
+	if fs.NArg() < 1 {
+		return errors.New(jiraBulkCreateUsage)
+	}
+
+	specs, err := loadIssueSpecs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		return fmt.Errorf("no issues in %s", fs.Arg(0))
+	}
+
+	defaults := jiraIssueSpec{Project: *project}
+	if *template != "" {
+		if defaults, err = loadIssueTemplate(*template); err != nil {
+			return err
+		}
+		if *project != "" {
+			defaults.Project = *project
+		}
+	}
+
+	// Resolve every issue before creating any, so a typo in row 20 doesn't
+	// leave the first 19 filed
+	var problems []string
+	payloads := make([]map[string]any, len(specs))
+	for i := range specs {
+		specs[i] = specs[i].withDefaults(defaults)
+		if payloads[i], err = p.issuePayload(ctx, specs[i]); err != nil {
+			if IsCancellation(err) {
+				return err
+			}
+			problems = append(problems, fmt.Sprintf("issue %d: %v", i+1, err))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("nothing created, fix these first:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	if *dryRun {
+		fmt.Printf("🔍 Dry run: %d issues would be created:\n", len(specs))
+		for i, spec := range specs {
+			fmt.Printf("   %d. %s\n", i+1, spec.describe())
+		}
+		return nil
+	}
+
+	failed := 0
+	for i, payload := range payloads {
+		key, err := p.createIssue(ctx, payload)
+		if IsCancellation(err) {
+			return err
+		}
+		if err != nil {
+			failed++
+			logger.Logger.Error().Err(err).Int("issue", i+1).Msg("Failed to create Jira issue")
+			fmt.Printf("   ❌ %d. %s: %v\n", i+1, specs[i].Summary, err)
+			continue
+		}
+		fmt.Printf("   ✅ %s: %s\n", key, specs[i].Summary)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issues could not be created", failed, len(specs))
+	}
+	fmt.Printf("\n✅ Created %d issues.\n", len(specs))
+	return nil
+}
+
+// issuePayload builds the create-issue request body, looking up custom field
+// IDs and assignee accounts as needed
+func (p *JiraPlugin) issuePayload(ctx context.Context, spec jiraIssueSpec) (map[string]any, error) {
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+
+	issueType := spec.Type
+	if issueType == "" {
+		issueType = "Task"
+	}
+	fields := map[string]any{
+		"project":   map[string]string{"key": spec.Project},
+		"summary":   spec.Summary,
+		"issuetype": map[string]string{"name": issueType},
+	}
+	if spec.Description != "" {
+		fields["description"] = p.richText(spec.Description)
+	}
+	if spec.Priority != "" {
+		fields["priority"] = map[string]string{"name": spec.Priority}
+	}
+	if len(spec.Labels) > 0 {
+		fields["labels"] = spec.Labels
+	}
+	if len(spec.Components) > 0 {
+		components := make([]map[string]string, len(spec.Components))
+		for i, name := range spec.Components {
+			components[i] = map[string]string{"name": name}
+		}
+		fields["components"] = components
+	}
+	if spec.Assignee != "" {
+		assignee, err := p.userRef(ctx, spec.Assignee)
+		if err != nil {
+			return nil, err
+		}
+		fields["assignee"] = assignee
+	}
+	if spec.Parent != "" {
+		fields["parent"] = map[string]string{"key": spec.Parent}
+	}
+
+	if spec.Epic != "" {
+		epicLink, err := p.epicLinkField(ctx)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case epicLink != nil:
+			fields[epicLink.ID] = spec.Epic
+		case spec.Parent == "" || spec.Parent == spec.Epic:
+			fields["parent"] = map[string]string{"key": spec.Epic}
+		default:
+			// Team-managed projects, and Cloud generally, link an epic as the parent
+			return nil, fmt.Errorf("epic %s and parent %s conflict: this site has no Epic Link field, so the epic is set as the parent; give only one",
+				spec.Epic, spec.Parent)
+		}
+	}
+
+	for name, raw := range spec.Fields {
+		field, err := p.lookupField(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.fieldValue(ctx, field, raw)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		fields[field.ID] = value
+	}
+
+	return map[string]any{"fields": fields}, nil
+}
+
+// CreateIssue files a Task with a summary and description, the create-issue
+// command without any of its options. With REST API v3 the description is
+// read as Markdown and sent as an ADF document; v2 passes it through as wiki markup.
+func (p *JiraPlugin) CreateIssue(ctx context.Context, projectKey, summary, description string) error {
+	payload, err := p.issuePayload(ctx, jiraIssueSpec{Project: projectKey, Summary: summary, Description: description})
+	if err != nil {
+		return err
+	}
+	_, err = p.createIssue(ctx, payload)
+	return err
+}
+
+// createIssue posts a payload from issuePayload and returns the new issue's key
+func (p *JiraPlugin) createIssue(ctx context.Context, payload map[string]any) (string, error) {
+	body, err := toJSON(payload)
+	if err != nil {
+		return "", wrapError("failed to prepare issue payload", err)
+	}
+
+	resp, err := p.makeRequest(ctx, "POST", p.restPath("issue"), bytes.NewReader(body))
+	if err != nil {
+		return "", wrapError("failed to create Jira issue", err)
+	}
+	defer HandleResponseBody(resp.Body)
+
+	respBody, _ := io.ReadAll(resp.Body)
+	logger.Logger.Debug().Str("JiraResponse", string(respBody)).Msg("Jira API response")
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected response from Jira: %s, response: %s", resp.Status, string(respBody))
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(respBody, &created); err != nil {
+		return "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	return created.Key, nil
+}
+
+// loadFields fetches the site's field definitions once per run
+func (p *JiraPlugin) loadFields(ctx context.Context) ([]jiraField, error) {
+	if p.fields != nil {
+		return p.fields, nil
+	}
+
+	resp, err := p.makeRequest(ctx, "GET", p.restPath("field"), nil)
+	if err != nil {
+		return nil, wrapError("failed to fetch Jira fields", err)
+	}
+	defer HandleResponseBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Jira fields: %s", resp.Status)
+	}
+
+	var fields []jiraField
+	if err := json.NewDecoder(resp.Body).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	p.fields = fields
+	return fields, nil
+}
+
+// lookupField finds a field by ID (customfield_10016) or, ignoring case, by name (Story Points)
+func (p *JiraPlugin) lookupField(ctx context.Context, name string) (jiraField, error) {
+	fields, err := p.loadFields(ctx)
+	if err != nil {
+		return jiraField{}, err
+	}
+	for _, field := range fields {
+		if field.ID == name {
+			return field, nil
+		}
+	}
+
+	var matches []jiraField
+	for _, field := range fields {
+		if strings.EqualFold(field.Name, name) {
+			matches = append(matches, field)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return jiraField{}, fmt.Errorf("unknown Jira field %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, field := range matches {
+			ids[i] = field.ID
+		}
+		return jiraField{}, fmt.Errorf("several Jira fields are named %q; use one of %s", name, strings.Join(ids, ", "))
+	}
+}
+
+// epicLinkField returns the Epic Link field, or nil on sites that link epics through parent
+func (p *JiraPlugin) epicLinkField(ctx context.Context) (*jiraField, error) {
+	fields, err := p.loadFields(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		if field.Schema.Custom == epicLinkType {
+			return &field, nil
+		}
+	}
+	return nil, nil
+}
+
+// fieldValue converts a string to the shape Jira expects for field's type
+func (p *JiraPlugin) fieldValue(ctx context.Context, field jiraField, raw string) (any, error) {
+	if field.Schema.Type == "array" {
+		items := splitList(raw)
+		values := make([]any, len(items))
+		for i, item := range items {
+			value, err := p.scalarValue(ctx, field.Schema.Items, item)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	if field.Schema.Type == "string" && strings.HasSuffix(field.Schema.Custom, ":textarea") {
+		return p.richText(raw), nil
+	}
+	return p.scalarValue(ctx, field.Schema.Type, raw)
+}
+
+func (p *JiraPlugin) scalarValue(ctx context.Context, schemaType, raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	switch schemaType {
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return n, nil
+	case "option":
+		return map[string]string{"value": raw}, nil
+	case "user":
+		return p.userRef(ctx, raw)
+	case "project", "issuelink":
+		return map[string]string{"key": raw}, nil
+	case "priority", "component", "version", "issuetype", "securitylevel":
+		return map[string]string{"name": raw}, nil
+	default:
+		return raw, nil
+	}
+}
+
+// userRef identifies a user the way the site expects: by account ID on Cloud,
+// by username on Server/Data Center. Email addresses are looked up.
+func (p *JiraPlugin) userRef(ctx context.Context, user string) (map[string]string, error) {
+	if strings.Contains(user, "@") {
+		found, err := p.findUser(ctx, user)
+		if err != nil {
+			return nil, wrapError("failed to look up Jira user "+user, err)
+		}
+		if found == nil {
+			return nil, fmt.Errorf("no Jira user found with email %s (use their account ID or username if the site hides emails)", user)
+		}
+		if found.AccountID != "" {
+			return map[string]string{"accountId": found.AccountID}, nil
+		}
+		return map[string]string{"name": found.Name}, nil
+	}
+	if p.isCloud() {
+		return map[string]string{"accountId": user}, nil
+	}
+	return map[string]string{"name": user}, nil
+}
+
+// loadIssueTemplate reads a single issue spec from a YAML file
+func loadIssueTemplate(path string) (jiraIssueSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return jiraIssueSpec{}, fmt.Errorf("failed to read template: %w", err)
+	}
+	var spec jiraIssueSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return jiraIssueSpec{}, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// loadIssueSpecs reads issues for bulk-create from a CSV or YAML file
+func loadIssueSpecs(path string) ([]jiraIssueSpec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return readIssueCSV(f)
+	case ".yaml", ".yml":
+		return readIssueYAML(f)
+	default:
+		return nil, fmt.Errorf("unsupported file type %s (expected .csv, .yaml or .yml)", path)
+	}
+}
+
+// readIssueYAML accepts either a list of issues or a document with shared
+// defaults and an issues list:
+//
+//	defaults: {project: CSYNC, labels: [follow-up]}
+//	issues:
+//	  - summary: Retry uploads on 5xx
+func readIssueYAML(r io.Reader) ([]jiraIssueSpec, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	if len(doc.Content) > 0 && doc.Content[0].Kind == yaml.SequenceNode {
+		var specs []jiraIssueSpec
+		if err := doc.Decode(&specs); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+		return specs, nil
+	}
+
+	var file struct {
+		Defaults jiraIssueSpec   `yaml:"defaults"`
+		Issues   []jiraIssueSpec `yaml:"issues"`
+	}
+	if err := doc.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	for i := range file.Issues {
+		file.Issues[i] = file.Issues[i].withDefaults(file.Defaults)
+	}
+	return file.Issues, nil
+}
+
+// readIssueCSV reads one issue per row. The header names the columns: project,
+// type, summary, description, priority, labels, components, assignee, parent
+// and epic; any other column is a field name or ID.
+func readIssueCSV(r io.Reader) ([]jiraIssueSpec, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	specs := make([]jiraIssueSpec, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		spec := jiraIssueSpec{Fields: make(map[string]string)}
+		for i, value := range row {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			switch column := strings.TrimSpace(header[i]); strings.ToLower(column) {
+			case "project":
+				spec.Project = value
+			case "type", "issuetype":
+				spec.Type = value
+			case "summary":
+				spec.Summary = value
+			case "description":
+				spec.Description = value
+			case "priority":
+				spec.Priority = value
+			case "labels":
+				spec.Labels = splitList(value)
+			case "components":
+				spec.Components = splitList(value)
+			case "assignee":
+				spec.Assignee = value
+			case "parent":
+				spec.Parent = value
+			case "epic":
+				spec.Epic = value
+			default:
+				spec.Fields[column] = value
+			}
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// splitList splits a comma- or semicolon-separated list, dropping blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,112 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCreateIssue(t *testing.T) {
+	var got struct {
+		Fields struct {
+			Project     map[string]string `json:"project"`
+			Summary     string            `json:"summary"`
+			IssueType   map[string]string `json:"issuetype"`
+			Description json.RawMessage   `json:"description"`
+		} `json:"fields"`
+	}
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/3/issue" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"key":"CS-7"}`))
+	}}
+	p := newJiraTestPlugin(t, "https://acme.atlassian.net", fake)
+	p.apiVersion = 3
+
+	if err := p.CreateIssue(context.Background(), "CS", "Retry uploads", "Retry on **5xx**"); err != nil {
+		t.Fatal(err)
+	}
+	if got.Fields.Project["key"] != "CS" || got.Fields.Summary != "Retry uploads" || got.Fields.IssueType["name"] != "Task" {
+		t.Errorf("fields = %+v, want a CS Task named Retry uploads", got.Fields)
+	}
+	var description struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(got.Fields.Description, &description); err != nil || description.Type != "doc" {
+		t.Errorf("description = %s, want an ADF document", got.Fields.Description)
+	}
+}
+
+func TestCreateIssueRequiresSummary(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+	if err := p.CreateIssue(context.Background(), "CS", "", "no summary"); err == nil {
+		t.Error("CreateIssue without a summary succeeded")
+	}
+}
+
+func TestCreateIssueCancelled(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := p.CreateIssue(ctx, "CS", "Retry uploads", ""); !IsCancellation(err) {
+		t.Errorf("CreateIssue with a cancelled context = %v, want a cancellation", err)
+	}
+}
+
+func TestIssuePayloadEpic(t *testing.T) {
+	epicLink := jiraField{ID: "customfield_10014", Name: "Epic Link"}
+	epicLink.Schema.Custom = epicLinkType
+
+	tests := []struct {
+		name       string
+		fields     []jiraField
+		parent     string
+		wantParent string
+		wantLink   string
+		wantErr    bool
+	}{
+		{name: "epic link field", fields: []jiraField{epicLink}, parent: "CS-2", wantParent: "CS-2", wantLink: "CS-1"},
+		{name: "epic as parent", fields: []jiraField{}, wantParent: "CS-1"},
+		{name: "epic is the parent", fields: []jiraField{}, parent: "CS-1", wantParent: "CS-1"},
+		{name: "epic and parent conflict", fields: []jiraField{}, parent: "CS-2", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &JiraPlugin{apiVersion: 2, fields: tc.fields}
+			spec := jiraIssueSpec{Project: "CS", Summary: "Retry uploads", Epic: "CS-1", Parent: tc.parent}
+
+			payload, err := p.issuePayload(context.Background(), spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("issuePayload = %v, want an error", payload)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			fields := payload["fields"].(map[string]any)
+			if parent, _ := fields["parent"].(map[string]string); parent["key"] != tc.wantParent {
+				t.Errorf("parent = %v, want %s", fields["parent"], tc.wantParent)
+			}
+			if link, _ := fields[epicLink.ID].(string); link != tc.wantLink {
+				t.Errorf("epic link = %q, want %q", link, tc.wantLink)
+			}
+		})
+	}
+}
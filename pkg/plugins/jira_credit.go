@@ -0,0 +1,492 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+)
+
+// Ways of deciding which Jira issues count as someone's work, chosen with plugins.jira.credit_by
+const (
+	jiraCreditActivity = "activity" // Changelog, comments and worklogs within the date range
+	jiraCreditAssignee = "assignee" // Current assignee, as csync originally did
+)
+
+// jiraDoneStatuses are status names treated as finishing an issue when no resolution is set
+var jiraDoneStatuses = []string{"done", "closed", "resolved"}
+
+// jiraHistory is one changelog entry: a set of field changes made by one user at once
+type jiraHistory struct {
+	Author  *jiraUser `json:"author"`
+	Created string    `json:"created"`
+	Items   []struct {
+		Field      string `json:"field"`
+		From       string `json:"from"`
+		FromString string `json:"fromString"`
+		To         string `json:"to"`
+		ToString   string `json:"toString"`
+	} `json:"items"`
+}
+
+type jiraWorklog struct {
+	Author           *jiraUser `json:"author"`
+	Started          string    `json:"started"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+}
+
+// buildActivityJQL finds issues the identities may have worked on: ones they
+// were ever assigned, transitioned, logged work on or reported. Comments can't
+// be searched in JQL except through Jira Cloud's updatedBy(), so Cloud sites
+// also match any issue the user updated in the range. Which of the matches
+// really count is decided afterwards from the changelog by creditReasons.
+func (p *JiraPlugin) buildActivityJQL(q Query, loc *time.Location) string {
+	// Prefer account IDs/usernames: Jira Cloud rejects JQL naming users by email
+	ids := q.Identities
+	if slices.ContainsFunc(ids, func(id contrib.Identity) bool { return id.Login != "" }) {
+		ids = slices.DeleteFunc(slices.Clone(ids), func(id contrib.Identity) bool { return id.Login == "" })
+	}
+
+	var anyOf []string
+	for _, id := range ids {
+		user := id.Login
+		if user == "" {
+			user = id.Email
+		}
+		if user == "" {
+			continue
+		}
+		u := jqlQuote(user)
+		anyOf = append(anyOf,
+			"assignee was "+u,
+			"status changed by "+u,
+			"worklogAuthor = "+u,
+			"reporter = "+u,
+		)
+		if p.isCloud() {
+			anyOf = append(anyOf, fmt.Sprintf("issuekey in updatedBy(%s%s)", u, updatedByRange(q, loc)))
+		}
+	}
+
+	if len(anyOf) == 0 {
+		return buildJQL(q, loc)
+	}
+
+	// Activity inside the range always bumps updated past Since, but the issue
+	// may have been updated again after Until, so only the lower bound applies
+	clauses := []string{"(" + strings.Join(anyOf, " OR ") + ")"}
+	if len(q.Targets) > 0 {
+		clauses = append(clauses, jqlProjects(q.Targets))
+	}
+	if !q.Since.IsZero() {
+		clauses = append(clauses, jqlUpdatedSince(q.Since, loc))
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY updated DESC"
+}
+
+// updatedByRange renders the optional date arguments of updatedBy() in loc
+func updatedByRange(q Query, loc *time.Location) string {
+	if q.Since.IsZero() && q.Until.IsZero() {
+		return ""
+	}
+	since, until := `""`, `""`
+	if !q.Since.IsZero() {
+		since = jqlQuote(q.Since.In(loc).Format("2006-01-02"))
+	}
+	if !q.Until.IsZero() {
+		until = jqlQuote(q.Until.In(loc).Format("2006-01-02"))
+	}
+	if q.Until.IsZero() {
+		return ", " + since
+	}
+	return ", " + since + ", " + until
+}
+
+// isCloud reports whether the site is Jira Cloud, which has account IDs and updatedBy()
+func (p *JiraPlugin) isCloud() bool {
+	return p.auth == jiraAuthOAuth || strings.Contains(p.baseURL, ".atlassian.net")
+}
+
+// fetchCreditedIssues returns the issues the query identities actually worked on
+// within the query's range, each with Metadata["credit"] listing why it counts
+func (p *JiraPlugin) fetchCreditedIssues(ctx context.Context, q Query) ([]contrib.Contribution, error) {
+	ids := p.resolveIdentities(ctx, q.Identities)
+	if len(ids) == 0 {
+		me, err := p.currentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = []contrib.Identity{me}
+	}
+	q.Identities = ids
+
+	// Issues are read a page at a time, so a limit saves fetching (and
+	// completing the history of) the issues after it
+	window := Query{Since: q.Since, Until: q.Until}
+	var credited []contrib.Contribution
+	jql := p.buildActivityJQL(q, p.jqlLocation(ctx, q.Since, q.Until))
+	fields := p.searchFields(ctx, slices.Concat(jiraTextFields, []string{"worklog"})...)
+	more, err := p.searchEach(ctx, jql, 0, fields, "changelog", func(issue jiraIssue) (bool, error) {
+		if err := p.completeHistory(ctx, &issue); err != nil {
+			return false, wrapError("failed to fetch the history of "+issue.Key, err)
+		}
+		reasons, who := creditReasons(issue, ids, window)
+		if len(reasons) == 0 {
+			return true, nil
+		}
+		c := p.issueContribution(issue)
+		c.Metadata["credit"] = reasons
+		if !c.AuthoredBy(ids) {
+			c.Authors = append(c.Authors, who)
+		}
+		credited = append(credited, c)
+		return q.Limit <= 0 || len(credited) < q.Limit, nil
+	})
+	// The issues left may not be credited, but telling would mean reading their history
+	if more {
+		p.truncated = true
+	}
+	return credited, err
+}
+
+// creditReasons explains why issue counts as work by ids within window, and
+// which identity did it. No reasons means they didn't touch it in that time.
+func creditReasons(issue jiraIssue, ids []contrib.Identity, window Query) ([]string, contrib.Identity) {
+	// who is the identity behind the first credited activity
+	var who *contrib.Identity
+	isUser := func(u *jiraUser) bool {
+		if u == nil {
+			return false
+		}
+		return slices.ContainsFunc(ids, u.identity().Matches)
+	}
+	creditTo := func(id contrib.Identity) {
+		if who == nil {
+			who = &id
+		}
+	}
+	inWindow := func(value string) bool {
+		t := parseJiraTime(value)
+		return !t.IsZero() && window.InRange(t)
+	}
+	userID := func(accountOrName string) (contrib.Identity, bool) {
+		for _, id := range ids {
+			if accountOrName != "" && accountOrName == id.Login {
+				return id, true
+			}
+		}
+		return contrib.Identity{}, false
+	}
+
+	var reasons []string
+	if isUser(issue.Fields.Reporter) && inWindow(issue.Fields.Created) {
+		reasons = append(reasons, contrib.CreditReported)
+		creditTo(issue.Fields.Reporter.identity())
+	}
+
+	// Walk the changelog oldest first, tracking the assignee so a resolution by
+	// someone else (or automation) still credits whoever held the issue then
+	histories := slices.Clone(issue.Changelog.Histories)
+	slices.SortStableFunc(histories, func(a, b jiraHistory) int {
+		return parseJiraTime(a.Created).Compare(parseJiraTime(b.Created))
+	})
+	assignee, assigneeWasUser := initialAssignee(histories, issue.Fields.Assignee, userID, isUser)
+	var movedTo string // The done status the user last moved the issue to
+	var transitioned, resolvedWhileAssigned bool
+	var transitionedBy, resolvedAssignee contrib.Identity
+	for _, h := range histories {
+		byUser := isUser(h.Author)
+		for _, item := range h.Items {
+			switch item.Field {
+			case "assignee":
+				assignee, assigneeWasUser = userID(item.To)
+			case "resolution", "status":
+				if !inWindow(h.Created) {
+					continue
+				}
+				done := item.Field == "resolution" && item.To != "" ||
+					item.Field == "status" && isDoneStatus(item.ToString)
+				switch {
+				case byUser && done:
+					movedTo = doneStatus(h, item.ToString)
+					transitionedBy = h.Author.identity()
+				case byUser:
+					transitioned = true
+					transitionedBy = h.Author.identity()
+				case done && assigneeWasUser:
+					resolvedWhileAssigned = true
+					resolvedAssignee = assignee
+				}
+			}
+		}
+	}
+	finished := movedTo != ""
+	if finished {
+		reasons = append(reasons, contrib.CreditMovedTo+movedTo)
+		creditTo(transitionedBy)
+	} else if resolvedWhileAssigned {
+		reasons = append(reasons, contrib.CreditAssigneeWhenResolved)
+		creditTo(resolvedAssignee)
+	}
+	if transitioned && !finished {
+		reasons = append(reasons, contrib.CreditTransitioned)
+		creditTo(transitionedBy)
+	}
+
+	comments := 0
+	for _, comment := range issue.Fields.Comment.Comments {
+		if isUser(comment.Author) && inWindow(comment.Created) {
+			comments++
+			creditTo(comment.Author.identity())
+		}
+	}
+	if comments > 0 {
+		reasons = append(reasons, fmt.Sprintf("%s(%d)", contrib.CreditCommented, comments))
+	}
+
+	var logged time.Duration
+	for _, worklog := range issue.Fields.Worklog.Worklogs {
+		if isUser(worklog.Author) && inWindow(worklog.Started) {
+			logged += time.Duration(worklog.TimeSpentSeconds) * time.Second
+			creditTo(worklog.Author.identity())
+		}
+	}
+	if logged > 0 {
+		reasons = append(reasons, contrib.CreditLogged+formatLogged(logged))
+	}
+
+	if who == nil {
+		return reasons, ids[0]
+	}
+	return reasons, *who
+}
+
+// formatLogged renders logged work to the minute as Jira does, e.g. 1h30m or 2h
+func formatLogged(d time.Duration) string {
+	text := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// initialAssignee returns the user's identity if the issue was assigned to
+// them before the first change in its changelog, given oldest first. With no
+// assignee changes that is whoever is assigned now.
+func initialAssignee(histories []jiraHistory, current *jiraUser, userID func(string) (contrib.Identity, bool), isUser func(*jiraUser) bool) (contrib.Identity, bool) {
+	for _, h := range histories {
+		for _, item := range h.Items {
+			if item.Field == "assignee" {
+				return userID(item.From)
+			}
+		}
+	}
+	if isUser(current) {
+		return current.identity(), true
+	}
+	return contrib.Identity{}, false
+}
+
+// doneStatus names the status a done transition moved an issue to. A
+// resolution change is named by the status change made with it, if any.
+func doneStatus(h jiraHistory, toString string) string {
+	for _, item := range h.Items {
+		if item.Field == "status" {
+			return item.ToString
+		}
+	}
+	return toString
+}
+
+// completeHistory fetches the rest of the issue's changelog and worklogs when
+// the search embedded only their first page: Cloud embeds at most 100 changelog
+// entries and 20 worklogs, and crediting needs all of them
+func (p *JiraPlugin) completeHistory(ctx context.Context, issue *jiraIssue) error {
+	if changelog := &issue.Changelog; changelog.Total > len(changelog.Histories) {
+		histories, err := fetchIssuePages[jiraHistory](ctx, p, issue.Key, "changelog", "values")
+		if err != nil {
+			return err
+		}
+		changelog.Histories = histories
+	}
+	if worklog := &issue.Fields.Worklog; worklog.Total > len(worklog.Worklogs) {
+		worklogs, err := fetchIssuePages[jiraWorklog](ctx, p, issue.Key, "worklog", "worklogs")
+		if err != nil {
+			return err
+		}
+		worklog.Worklogs = worklogs
+	}
+	return nil
+}
+
+// fetchIssuePages reads every page of an issue sub-resource such as
+// /issue/{key}/worklog, whose entries are listed under list
+func fetchIssuePages[T any](ctx context.Context, p *JiraPlugin, key, resource, list string) ([]T, error) {
+	var all []T
+	for {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(all)))
+		params.Set("maxResults", strconv.Itoa(jiraPageSize))
+		endpoint := p.restPath("issue/"+url.PathEscape(key)+"/"+resource) + "?" + params.Encode()
+
+		resp, err := p.makeRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			HandleResponseBody(resp.Body)
+			return nil, fmt.Errorf("%s request failed: %s", resource, resp.Status)
+		}
+		var page map[string]json.RawMessage
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		HandleResponseBody(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response: %v", err)
+		}
+
+		var entries []T
+		var total int
+		if err := json.Unmarshal(page[list], &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", resource, err)
+		}
+		if raw, ok := page["total"]; ok {
+			if err := json.Unmarshal(raw, &total); err != nil {
+				return nil, fmt.Errorf("failed to parse %s total: %v", resource, err)
+			}
+		}
+		all = append(all, entries...)
+		if len(entries) == 0 || len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+func isDoneStatus(status string) bool {
+	for _, done := range jiraDoneStatuses {
+		if strings.EqualFold(status, done) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIdentities adds the account ID (Cloud) or username (Server) of each
+// email-only identity, since changelog authors rarely expose email addresses
+func (p *JiraPlugin) resolveIdentities(ctx context.Context, ids []contrib.Identity) []contrib.Identity {
+	resolved := append([]contrib.Identity(nil), ids...)
+	for _, id := range ids {
+		if id.Login != "" || id.Email == "" {
+			continue
+		}
+		user, err := p.findUser(ctx, id.Email)
+		if err != nil {
+			logger.Logger.Debug().Err(err).Str("email", id.Email).Msg("Could not resolve Jira user")
+			continue
+		}
+		if user != nil {
+			resolved = append(resolved, user.identity())
+		}
+	}
+	return resolved
+}
+
+// findUser looks a user up by email, returning nil if there is no exact match
+func (p *JiraPlugin) findUser(ctx context.Context, email string) (*jiraUser, error) {
+	param := "username" // Server/Data Center
+	if p.isCloud() {
+		param = "query"
+	}
+	resp, err := p.makeRequest(ctx, "GET", p.restPath("user/search")+"?"+param+"="+url.QueryEscape(email), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer HandleResponseBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("user search failed: %s", resp.Status)
+	}
+
+	var users []jiraUser
+	if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	// The search also matches names and partial emails, so only an exact email
+	// is trusted. Cloud hides emails unless users share them, leaving nothing to check.
+	hidden := 0
+	for _, user := range users {
+		if strings.EqualFold(user.EmailAddress, email) {
+			return &user, nil
+		}
+		if user.EmailAddress == "" {
+			hidden++
+		}
+	}
+	if hidden > 0 {
+		logger.Logger.Warn().Str("email", email).Int("candidates", hidden).
+			Msg("Jira hides the email of users matching this address, so none can be confirmed; set identity.jira_account_id instead")
+	}
+	return nil, nil
+}
+
+// currentUser returns the identity the credentials belong to
+func (p *JiraPlugin) currentUser(ctx context.Context) (contrib.Identity, error) {
+	me, err := p.myself(ctx)
+	if err != nil {
+		return contrib.Identity{}, err
+	}
+	return me.identity(), nil
+}
+
+// myself fetches the user the credentials belong to
+func (p *JiraPlugin) myself(ctx context.Context) (*jiraUser, error) {
+	resp, err := p.makeRequest(ctx, "GET", p.restPath("myself"), nil)
+	if err != nil {
+		return nil, wrapError("failed to look up the current Jira user", err)
+	}
+	defer HandleResponseBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to look up the current Jira user: %s", resp.Status)
+	}
+
+	var me jiraUser
+	if err := json.NewDecoder(resp.Body).Decode(&me); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %v", err)
+	}
+	return &me, nil
+}
+
+// jqlLocation returns the time zone Jira reads JQL dates in: that of the
+// user's profile. It is only looked up when since or until is set, and falls
+// back to the local zone, with a warning, if it can't be.
+func (p *JiraPlugin) jqlLocation(ctx context.Context, since, until time.Time) *time.Location {
+	if p.location != nil {
+		return p.location
+	}
+	if since.IsZero() && until.IsZero() {
+		return time.Local
+	}
+
+	p.location = time.Local
+	me, err := p.myself(ctx)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Msg("Could not look up your Jira time zone; date ranges use the local one")
+		return p.location
+	}
+	if me.TimeZone == "" {
+		return p.location
+	}
+	loc, err := time.LoadLocation(me.TimeZone)
+	if err != nil {
+		logger.Logger.Warn().Err(err).Str("time_zone", me.TimeZone).Msg("Unknown Jira time zone; date ranges use the local one")
+		return p.location
+	}
+	p.location = loc
+	return loc
+}
@@ -0,0 +1,298 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+// creditWindow is the third quarter of 2026
+var creditWindow = Query{
+	Since: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+	Until: time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC),
+}
+
+func parseIssue(t *testing.T, data string) jiraIssue {
+	t.Helper()
+	var issue jiraIssue
+	if err := json.Unmarshal([]byte(data), &issue); err != nil {
+		t.Fatalf("invalid issue JSON: %v\n%s", err, data)
+	}
+	return issue
+}
+
+// jiraChange is a changelog entry by author changing one field
+func jiraChange(author, created, field, from, to, toString string) string {
+	return fmt.Sprintf(`{"author":{"accountId":%q},"created":%q,"items":[{"field":%q,"from":%q,"to":%q,"toString":%q}]}`,
+		author, created, field, from, to, toString)
+}
+
+func TestCreditReasons(t *testing.T) {
+	me := []contrib.Identity{{Email: "me@example.com", Login: "acc-me"}}
+	tests := []struct {
+		name   string
+		fields string // Extra issue fields
+		log    []string
+		want   []string
+	}{
+		{
+			name:   "reporter",
+			fields: `"reporter":{"accountId":"acc-me"},"created":"2026-08-01T09:00:00.000+0000"`,
+			want:   []string{"reported"},
+		},
+		{
+			name:   "reported before the window",
+			fields: `"reporter":{"accountId":"acc-me"},"created":"2026-05-01T09:00:00.000+0000"`,
+		},
+		{
+			name: "moved to done",
+			log:  []string{jiraChange("acc-me", "2026-08-10T12:00:00.000+0000", "status", "3", "10001", "Done")},
+			want: []string{"moved to Done"},
+		},
+		{
+			// Credits the transition the user made, not where someone else later moved it
+			name:   "moved to done, reopened by someone else",
+			fields: `"status":{"name":"Reopened"}`,
+			log: []string{
+				jiraChange("acc-me", "2026-08-10T12:00:00.000+0000", "status", "3", "10002", "Closed"),
+				jiraChange("acc-other", "2026-08-12T12:00:00.000+0000", "status", "10002", "4", "Reopened"),
+			},
+			want: []string{"moved to Closed"},
+		},
+		{
+			name: "resolved with a status change",
+			log: []string{`{"author":{"accountId":"acc-me"},"created":"2026-08-10T12:00:00.000+0000","items":[` +
+				`{"field":"resolution","from":"","to":"10000","toString":"Fixed"},` +
+				`{"field":"status","from":"3","to":"10001","toString":"Done"}]}`},
+			want: []string{"moved to Done"},
+		},
+		{
+			name: "transitioned",
+			log:  []string{jiraChange("acc-me", "2026-08-10T12:00:00.000+0000", "status", "1", "3", "In Progress")},
+			want: []string{"transitioned"},
+		},
+		{
+			// Listed newest first, as some Jira versions embed them: the
+			// reassignment after the resolution must not hide the credit
+			name:   "assignee when automation resolved",
+			fields: `"assignee":{"accountId":"acc-other"}`,
+			log: []string{
+				jiraChange("acc-other", "2026-08-20T12:00:00.000+0000", "assignee", "acc-me", "acc-other", "Other"),
+				jiraChange("automation", "2026-08-10T12:00:00.000+0000", "resolution", "", "10000", "Done"),
+				jiraChange("acc-lead", "2026-07-05T12:00:00.000+0000", "assignee", "", "acc-me", "Me"),
+			},
+			want: []string{"assignee when resolved"},
+		},
+		{
+			name:   "assigned only after automation resolved",
+			fields: `"assignee":{"accountId":"acc-me"}`,
+			log: []string{
+				jiraChange("acc-lead", "2026-08-20T12:00:00.000+0000", "assignee", "acc-other", "acc-me", "Me"),
+				jiraChange("automation", "2026-08-10T12:00:00.000+0000", "resolution", "", "10000", "Done"),
+			},
+		},
+		{
+			name:   "assignee throughout",
+			fields: `"assignee":{"accountId":"acc-me"}`,
+			log:    []string{jiraChange("automation", "2026-08-10T12:00:00.000+0000", "status", "3", "10001", "Closed")},
+			want:   []string{"assignee when resolved"},
+		},
+		{
+			name: "resolved before the window",
+			log:  []string{jiraChange("acc-me", "2026-06-10T12:00:00.000+0000", "resolution", "", "10000", "Done")},
+		},
+		{
+			name: "worklog",
+			fields: `"worklog":{"total":3,"worklogs":[` +
+				`{"author":{"accountId":"acc-me"},"started":"2026-08-02T09:00:00.000+0000","timeSpentSeconds":3600},` +
+				`{"author":{"accountId":"acc-me"},"started":"2026-08-03T09:00:00.000+0000","timeSpentSeconds":1800},` +
+				`{"author":{"accountId":"acc-other"},"started":"2026-08-03T09:00:00.000+0000","timeSpentSeconds":7200}]}`,
+			want: []string{"logged 1h30m"},
+		},
+		{
+			name: "worklog outside the window",
+			fields: `"worklog":{"total":1,"worklogs":[` +
+				`{"author":{"accountId":"acc-me"},"started":"2026-10-02T09:00:00.000+0000","timeSpentSeconds":3600}]}`,
+		},
+		{
+			name: "comments",
+			fields: `"comment":{"comments":[` +
+				`{"author":{"accountId":"acc-me"},"created":"2026-07-02T09:00:00.000+0000","body":"a"},` +
+				`{"author":{"emailAddress":"ME@example.com"},"created":"2026-07-03T09:00:00.000+0000","body":"b"},` +
+				`{"author":{"accountId":"acc-me"},"created":"2026-06-30T09:00:00.000+0000","body":"c"}]}`,
+			want: []string{"commented (2)"},
+		},
+		{
+			name:   "everything outside the window",
+			fields: `"reporter":{"accountId":"acc-me"},"created":"2026-01-01T09:00:00.000+0000","assignee":{"accountId":"acc-me"}`,
+			log:    []string{jiraChange("acc-me", "2026-10-01T12:00:00.000+0000", "status", "3", "10001", "Done")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := `"status":{"name":"Done"}`
+			if tc.fields != "" {
+				fields += "," + tc.fields
+			}
+			issue := parseIssue(t, fmt.Sprintf(`{"key":"CS-1","fields":{%s},"changelog":{"histories":[%s]}}`,
+				fields, strings.Join(tc.log, ",")))
+
+			got, _ := creditReasons(issue, me, creditWindow)
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("creditReasons = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCreditReasonsIdentity(t *testing.T) {
+	// Two accounts for the same person, e.g. after a migration
+	ids := []contrib.Identity{{Login: "acc-old"}, {Login: "acc-me"}}
+	issue := parseIssue(t, `{"key":"CS-1","fields":{"status":{"name":"Done"},`+
+		`"comment":{"comments":[{"author":{"accountId":"acc-me","displayName":"Me"},"created":"2026-08-02T09:00:00.000+0000","body":"a"}]},`+
+		`"worklog":{"total":1,"worklogs":[{"author":{"accountId":"acc-old"},"started":"2026-10-02T09:00:00.000+0000","timeSpentSeconds":3600}]}},`+
+		`"changelog":{"histories":[`+jiraChange("acc-old", "2026-05-10T12:00:00.000+0000", "status", "3", "10001", "Done")+`]}}`)
+
+	reasons, who := creditReasons(issue, ids, creditWindow)
+	if !slices.Equal(reasons, []string{"commented (1)"}) {
+		t.Errorf("creditReasons = %q, want only the comment", reasons)
+	}
+	// Activity outside the window by the other account must not change who is credited
+	if who.Login != "acc-me" || who.Name != "Me" {
+		t.Errorf("credited %+v, want the commenting account", who)
+	}
+}
+
+func TestFetchCreditedIssuesPagesHistory(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		startAt := r.URL.Query().Get("startAt")
+		switch {
+		case r.URL.Path == "/rest/api/2/myself":
+			fmt.Fprint(w, `{"name":"me","timeZone":"America/New_York"}`)
+		case r.URL.Path == "/rest/api/2/search":
+			// The range starts at midnight UTC, which is 8pm the day before in the user's zone
+			if jql := r.URL.Query().Get("jql"); !strings.Contains(jql, `updated >= "2026-06-30 20:00"`) {
+				t.Errorf("jql = %q, want the range in the user's time zone", jql)
+			}
+			// Only the newest changelog entry and no worklogs embedded
+			fmt.Fprintf(w, `{"total":1,"issues":[{"key":"CS-1","fields":{"status":{"name":"Done"},"worklog":{"total":2,"worklogs":[]}},`+
+				`"changelog":{"total":3,"histories":[%s]}}]}`,
+				jiraChange("other", "2026-08-20T12:00:00.000+0000", "assignee", "me", "other", "Other"))
+		case r.URL.Path == "/rest/api/2/issue/CS-1/changelog" && startAt == "0":
+			fmt.Fprintf(w, `{"startAt":0,"maxResults":2,"total":3,"values":[%s,%s]}`,
+				jiraChange("lead", "2026-07-05T12:00:00.000+0000", "assignee", "", "me", "Me"),
+				jiraChange("automation", "2026-08-10T12:00:00.000+0000", "resolution", "", "10000", "Done"))
+		case r.URL.Path == "/rest/api/2/issue/CS-1/changelog" && startAt == "2":
+			fmt.Fprintf(w, `{"startAt":2,"maxResults":2,"total":3,"values":[%s]}`,
+				jiraChange("other", "2026-08-20T12:00:00.000+0000", "assignee", "me", "other", "Other"))
+		case r.URL.Path == "/rest/api/2/issue/CS-1/worklog" && startAt == "0":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":1,"total":2,"worklogs":[{"author":{"name":"me"},"started":"2026-08-02T09:00:00.000+0000","timeSpentSeconds":3600}]}`)
+		case r.URL.Path == "/rest/api/2/issue/CS-1/worklog" && startAt == "1":
+			fmt.Fprint(w, `{"startAt":1,"maxResults":1,"total":2,"worklogs":[{"author":{"name":"me"},"started":"2026-08-03T09:00:00.000+0000","timeSpentSeconds":3600}]}`)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+	p.creditBy = jiraCreditActivity
+
+	q := creditWindow
+	q.Identities = []contrib.Identity{{Login: "me"}}
+	issues, err := p.fetchCreditedIssues(context.Background(), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("credited %d issues, want 1", len(issues))
+	}
+	want := []string{"assignee when resolved", "logged 2h"}
+	if got := issues[0].MetadataStrings("credit"); !slices.Equal(got, want) {
+		t.Errorf("credit = %q, want %q", got, want)
+	}
+}
+
+func TestFetchCreditedIssuesStopsAtLimit(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			fmt.Fprint(w, `{"name":"me","timeZone":"UTC"}`)
+		case "/rest/api/2/search":
+			// One issue a page, each reported by the user within the range
+			startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			fmt.Fprintf(w, `{"startAt":%d,"maxResults":1,"total":3,"issues":[{"key":"CS-%d","fields":{"status":{"name":"Done"},`+
+				`"reporter":{"name":"me"},"created":"2026-07-10T09:00:00.000+0000"},"changelog":{"total":0,"histories":[]}}]}`, startAt, startAt+1)
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+	p.creditBy = jiraCreditActivity
+
+	q := creditWindow
+	q.Identities = []contrib.Identity{{Login: "me"}}
+	q.Limit = 2
+	issues, err := p.Fetch(context.Background(), q)
+	if !IsTruncated(err) {
+		t.Errorf("err = %v, want the results reported as truncated", err)
+	}
+	if len(issues) != 2 || issues[1].ID != "CS-2" {
+		t.Errorf("issues = %+v, want CS-1 and CS-2", issues)
+	}
+	var searches int
+	for _, req := range fake.seen() {
+		if req.Path == "/rest/api/2/search" {
+			searches++
+		}
+	}
+	if searches != 2 {
+		t.Errorf("searched %d pages, want 2 (stopping at the limit)", searches)
+	}
+}
+
+func TestFindUserRequiresExactEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		users string
+		want  string // Account ID found, "" for none
+	}{
+		{"exact match", `[{"accountId":"a1","emailAddress":"Me@Example.com"}]`, "a1"},
+		{"exact match among several", `[{"accountId":"a1","emailAddress":"me@example.com.au"},{"accountId":"a2","emailAddress":"me@example.com"}]`, "a2"},
+		{"only user has another email", `[{"accountId":"a1","emailAddress":"me@example.com.au"}]`, ""},
+		{"only user hides email", `[{"accountId":"a1","displayName":"Me"}]`, ""},
+		{"no users", `[]`, ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/user/search" || r.URL.Query().Get("query") != "me@example.com" {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				fmt.Fprint(w, tc.users)
+			}}
+			p := newJiraTestPlugin(t, "https://acme.atlassian.net", fake)
+			p.apiVersion = 3
+
+			user, err := p.findUser(context.Background(), "me@example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got string
+			if user != nil {
+				got = user.AccountID
+			}
+			if got != tc.want {
+				t.Errorf("findUser = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
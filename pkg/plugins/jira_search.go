@@ -0,0 +1,169 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+const jiraSearchUsage = `usage: search "<jql>" | search --query NAME [--since DATE] [--until DATE] [--limit N]`
+
+const jiraFilterUsage = "usage: filter <id|name> [--since DATE] [--until DATE] [--limit N]"
+
+// orderByPattern finds the ORDER BY clause, which must stay at the end of a JQL query
+var orderByPattern = regexp.MustCompile(`(?i)(?:^|\s+)order\s+by\s+`)
+
+// executeSearch runs `jira search`: an ad-hoc JQL query or one saved under plugins.jira.queries
+func (p *JiraPlugin) executeSearch(ctx context.Context, args []string) error {
+	fs := newFlagSet("jira search")
+	dates := addRangeFlags(fs)
+	limit := addLimitFlag(fs)
+	name := fs.String("query", "", "Run the JQL saved under this name in plugins.jira.queries")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%s", err, jiraSearchUsage)
+	}
+	r, err := dates.parse()
+	if err != nil {
+		return err
+	}
+
+	var jql, title string
+	switch {
+	case *name != "":
+		if jql, err = p.namedQuery(*name); err != nil {
+			return err
+		}
+		title = *name
+	case fs.NArg() >= 1:
+		jql = strings.Join(fs.Args(), " ")
+		title = jql
+	default:
+		return errors.New(jiraSearchUsage)
+	}
+
+	return p.printSearch(ctx, title, scopeJQL(jql, r, p.jqlLocation(ctx, r.Since, r.Until)), *limit)
+}
+
+// executeFilter runs `jira filter`: the JQL of a saved Jira filter
+func (p *JiraPlugin) executeFilter(ctx context.Context, args []string) error {
+	fs := newFlagSet("jira filter")
+	dates := addRangeFlags(fs)
+	limit := addLimitFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%s", err, jiraFilterUsage)
+	}
+	if fs.NArg() < 1 {
+		return errors.New(jiraFilterUsage)
+	}
+	r, err := dates.parse()
+	if err != nil {
+		return err
+	}
+
+	filter := strings.Join(fs.Args(), " ")
+	title, jql, err := p.filterJQL(ctx, filter)
+	if err != nil {
+		return err
+	}
+	return p.printSearch(ctx, "filter "+title, scopeJQL(jql, r, p.jqlLocation(ctx, r.Since, r.Until)), *limit)
+}
+
+func (p *JiraPlugin) printSearch(ctx context.Context, title, jql string, limit int) error {
+	issues, err := p.searchIssues(ctx, jql, limit, p.searchFields(ctx))
+	if err != nil {
+		return wrapError("failed to search Jira issues", err)
+	}
+
+	if len(issues) == 0 {
+		fmt.Printf("\n📌 No issues match **%s**.\n", title)
+		return nil
+	}
+
+	fmt.Printf("\n📌 Issues matching **%s** (%d):\n", title, len(issues))
+	for _, issue := range issues {
+		fmt.Printf("   - [%s] (%s) %s\n", issue.ID, issue.Metadata["issuetype"], issue.Title)
+		fmt.Printf("     🔹 Status: %s | 📅 Updated: %s\n", issue.Status, issue.UpdatedAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// namedQuery returns the JQL saved under name in plugins.jira.queries. Names
+// are case-insensitive, as viper lowercases the keys it reads from config.yaml.
+func (p *JiraPlugin) namedQuery(name string) (string, error) {
+	jql, ok := p.queries[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("no Jira query named %q; add it under plugins.jira.queries", name)
+	}
+	return jql, nil
+}
+
+// filterJQL resolves a saved filter to its name and JQL. Numeric IDs are
+// looked up so the filter's own ordering is kept; names are matched by JQL's
+// filter clause, which works the same on Cloud and Data Center.
+func (p *JiraPlugin) filterJQL(ctx context.Context, filter string) (string, string, error) {
+	if _, err := strconv.Atoi(filter); err != nil {
+		return filter, "filter = " + jqlQuote(filter), nil
+	}
+
+	resp, err := p.makeRequest(ctx, "GET", p.restPath("filter/"+url.PathEscape(filter)), nil)
+	if err != nil {
+		return "", "", wrapError("failed to fetch Jira filter", err)
+	}
+	defer HandleResponseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", "", fmt.Errorf("failed to fetch Jira filter %s, status: %s, response: %s", filter, resp.Status, string(body))
+	}
+
+	var result struct {
+		Name string `json:"name"`
+		JQL  string `json:"jql"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("failed to parse response: %v", err)
+	}
+	return result.Name, result.JQL, nil
+}
+
+// scopeJQL restricts a user-supplied JQL query to issues updated in r, written
+// in loc, keeping its ORDER BY (or ordering by most recently updated if it has none)
+func scopeJQL(jql string, r daterange.Range, loc *time.Location) string {
+	where, order := jql, "updated DESC"
+	if idx := orderByPattern.FindStringIndex(jql); idx != nil {
+		where, order = jql[:idx[0]], jql[idx[1]:]
+	}
+
+	var clauses []string
+	if where = strings.TrimSpace(where); where != "" {
+		clauses = append(clauses, "("+where+")")
+	}
+	if !r.Since.IsZero() {
+		clauses = append(clauses, jqlUpdatedSince(r.Since, loc))
+	}
+	if !r.Until.IsZero() {
+		clauses = append(clauses, jqlUpdatedUntil(r.Until, loc))
+	}
+	return strings.Join(clauses, " AND ") + " ORDER BY " + order
+}
+
+// fetchNamed returns the issues matched by the query named in q.Named, within q's date range
+func (p *JiraPlugin) fetchNamed(ctx context.Context, q Query) ([]contrib.Contribution, error) {
+	jql, err := p.namedQuery(q.Named)
+	if err != nil {
+		return nil, err
+	}
+	r := daterange.Range{Since: q.Since, Until: q.Until}
+	return p.searchIssues(ctx, scopeJQL(jql, r, p.jqlLocation(ctx, q.Since, q.Until)), q.Limit, p.searchFields(ctx))
+}
@@ -0,0 +1,351 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+// fakeJira serves canned responses by path and records every request
+type fakeJira struct {
+	mu       sync.Mutex
+	requests []*url.URL
+	handle   func(w http.ResponseWriter, r *http.Request)
+}
+
+func (f *fakeJira) seen() []*url.URL {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.requests)
+}
+
+// newJiraTestPlugin points a JiraPlugin at a fake server. Requests go to the
+// server whatever the base URL's host, so Cloud sites can be faked too.
+func newJiraTestPlugin(t *testing.T, baseURL string, f *fakeJira) *JiraPlugin {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r.URL)
+		f.mu.Unlock()
+		f.handle(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	target, _ := url.Parse(server.URL)
+	previous := http.DefaultTransport
+	http.DefaultTransport = redirectTransport{target: target, base: previous}
+	t.Cleanup(func() { http.DefaultTransport = previous })
+
+	return &JiraPlugin{
+		baseURL:    baseURL,
+		email:      "me@example.com",
+		apiToken:   "token",
+		apiVersion: 2,
+		creditBy:   jiraCreditAssignee,
+		agile:      &jiraAgileFields{}, // Skip the field lookup
+	}
+}
+
+type redirectTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = t.target.Scheme, t.target.Host
+	return t.base.RoundTrip(req)
+}
+
+func jiraIssueJSON(key string) string {
+	return fmt.Sprintf(`{"key":%q,"fields":{"summary":"Issue %s","project":{"key":"CS"},"status":{"name":"Done"},`+
+		`"issuetype":{"name":"Task"},"updated":"2026-08-01T10:00:00.000+0000"}}`, key, key)
+}
+
+func issueList(keys ...string) string {
+	issues := make([]string, len(keys))
+	for i, key := range keys {
+		issues[i] = jiraIssueJSON(key)
+	}
+	return strings.Join(issues, ",")
+}
+
+func TestSearchIssuesCloudUsesNextPageToken(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/search/jql" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		switch r.URL.Query().Get("nextPageToken") {
+		case "":
+			fmt.Fprintf(w, `{"issues":[%s],"nextPageToken":"page-2","isLast":false}`, issueList("CS-1", "CS-2"))
+		case "page-2":
+			fmt.Fprintf(w, `{"issues":[%s],"isLast":true}`, issueList("CS-3"))
+		default:
+			t.Errorf("unexpected token %q", r.URL.Query().Get("nextPageToken"))
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://acme.atlassian.net", fake)
+	p.apiVersion = 3
+
+	issues, err := p.searchIssues(context.Background(), "project = CS", 0, p.searchFields(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, issue := range issues {
+		keys = append(keys, issue.ID)
+	}
+	if !slices.Equal(keys, []string{"CS-1", "CS-2", "CS-3"}) {
+		t.Errorf("issues = %v, want CS-1..CS-3", keys)
+	}
+
+	requests := fake.seen()
+	if len(requests) != 2 {
+		t.Fatalf("made %d requests, want 2", len(requests))
+	}
+	for _, req := range requests {
+		if req.Query().Has("startAt") {
+			t.Errorf("Cloud request %s sent startAt", req)
+		}
+	}
+	if got := requests[0].Query().Get("jql"); got != "project = CS" {
+		t.Errorf("jql = %q", got)
+	}
+}
+
+func TestSearchIssuesServerUsesStartAt(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		// Jira may return fewer issues than maxResults, so paging must follow startAt/total
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			fmt.Fprintf(w, `{"startAt":0,"maxResults":2,"total":3,"issues":[%s]}`, issueList("CS-1", "CS-2"))
+		case "2":
+			fmt.Fprintf(w, `{"startAt":2,"maxResults":2,"total":3,"issues":[%s]}`, issueList("CS-3"))
+		default:
+			t.Errorf("unexpected startAt %q", r.URL.Query().Get("startAt"))
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+
+	issues, err := p.searchIssues(context.Background(), "project = CS", 0, p.searchFields(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 || issues[2].ID != "CS-3" {
+		t.Errorf("issues = %+v, want CS-1..CS-3", issues)
+	}
+	if n := len(fake.seen()); n != 2 {
+		t.Errorf("made %d requests, want 2", n)
+	}
+}
+
+func TestSearchIssuesCloudStopsAtLimit(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"issues":[%s],"nextPageToken":"more"}`, issueList("CS-1", "CS-2"))
+	}}
+	p := newJiraTestPlugin(t, "https://acme.atlassian.net", fake)
+
+	issues, err := p.searchIssues(context.Background(), "project = CS", 2, p.searchFields(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := fake.seen()
+	if len(issues) != 2 || len(requests) != 1 {
+		t.Fatalf("got %d issues in %d requests, want 2 in 1", len(issues), len(requests))
+	}
+	if requests[0].Path != "/rest/api/2/search/jql" || requests[0].Query().Get("maxResults") != "2" {
+		t.Errorf("request = %s, want search/jql with maxResults=2", requests[0])
+	}
+}
+
+func TestFetchTruncatedOnlyWhenIssuesRemain(t *testing.T) {
+	for _, total := range []int{2, 3} {
+		t.Run(fmt.Sprintf("%d matching", total), func(t *testing.T) {
+			fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"startAt":0,"maxResults":2,"total":%d,"issues":[%s]}`, total, issueList("CS-1", "CS-2"))
+			}}
+			p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+
+			issues, err := p.Fetch(context.Background(), Query{Targets: []string{"CS"}, Limit: 2})
+			if len(issues) != 2 {
+				t.Errorf("got %d issues, want 2", len(issues))
+			}
+			if IsTruncated(err) != (total > 2) {
+				t.Errorf("err = %v with %d matching issues and a limit of 2", err, total)
+			}
+		})
+	}
+}
+
+func TestSearchFieldsOnlyRequestTextWhenRead(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/search"):
+			fmt.Fprintf(w, `{"total":1,"issues":[%s]}`, issueList("CS-1"))
+		case strings.HasSuffix(r.URL.Path, "/issue/CS-1"):
+			fmt.Fprint(w, jiraIssueJSON("CS-1"))
+		default:
+			http.NotFound(w, r)
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantText bool
+	}{
+		{name: "list-issues", call: func() error { return p.listIssues(ctx, "CS", 0) }},
+		{name: "search", call: func() error { return p.executeSearch(ctx, []string{"project = CS"}) }},
+		{name: "fetch", call: func() error { _, err := p.Fetch(ctx, Query{Targets: []string{"CS"}}); return err }},
+		{name: "summary issues", call: func() error {
+			_, err := p.fetchUserIssues(ctx, "me@example.com", daterange.Range{}, 0, true)
+			return err
+		}, wantText: true},
+		{name: "show", call: func() error { return p.showIssue(ctx, "CS-1") }, wantText: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			before := len(fake.seen())
+			if err := tc.call(); err != nil {
+				t.Fatal(err)
+			}
+			requests := fake.seen()[before:]
+			if len(requests) == 0 {
+				t.Fatal("no request made")
+			}
+			fields := strings.Split(requests[0].Query().Get("fields"), ",")
+			for _, field := range jiraTextFields {
+				if got := slices.Contains(fields, field); got != tc.wantText {
+					t.Errorf("fields %v: has %s = %v, want %v", fields, field, got, tc.wantText)
+				}
+			}
+			if !slices.Contains(fields, "summary") {
+				t.Errorf("fields %v lack summary", fields)
+			}
+		})
+	}
+}
+
+func TestScopeJQLUsesJiraTimeZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	r := daterange.Range{
+		Since: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2026, 9, 30, 23, 59, 59, 0, time.UTC),
+	}
+
+	got := scopeJQL("project = CS ORDER BY created", r, tokyo)
+	want := `(project = CS) AND updated >= "2026-07-01 09:00" AND updated < "2026-10-01 09:00" ORDER BY created`
+	if got != want {
+		t.Errorf("scopeJQL = %q, want %q", got, want)
+	}
+}
+
+func TestScopeJQL(t *testing.T) {
+	r := daterange.Range{Since: time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2026, 7, 31, 23, 59, 59, 0, time.UTC)}
+	bounds := `updated >= "2026-07-01 00:00" AND updated < "2026-08-01 00:00"`
+
+	tests := []struct {
+		name string
+		jql  string
+		r    daterange.Range
+		want string
+	}{
+		{name: "where and order", jql: "project = CS ORDER BY priority DESC", r: r, want: "(project = CS) AND " + bounds + " ORDER BY priority DESC"},
+		{name: "lowercase order by", jql: "labels = perf order by created", r: r, want: "(labels = perf) AND " + bounds + " ORDER BY created"},
+		{name: "where only", jql: "project = CS OR project = OPS", r: r, want: "(project = CS OR project = OPS) AND " + bounds + " ORDER BY updated DESC"},
+		{name: "order only", jql: "ORDER BY rank", r: r, want: bounds + " ORDER BY rank"},
+		{name: "no range", jql: "project = CS", want: "(project = CS) ORDER BY updated DESC"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := scopeJQL(tc.jql, tc.r, time.UTC); got != tc.want {
+				t.Errorf("scopeJQL = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNamedQuery(t *testing.T) {
+	p := &JiraPlugin{queries: map[string]string{"team-bugs": "issuetype = Bug"}}
+
+	for _, name := range []string{"team-bugs", "Team-Bugs"} {
+		if jql, err := p.namedQuery(name); err != nil || jql != "issuetype = Bug" {
+			t.Errorf("namedQuery(%q) = %q, %v; want the team-bugs JQL", name, jql, err)
+		}
+	}
+	if _, err := p.namedQuery("on-call"); err == nil || !strings.Contains(err.Error(), "plugins.jira.queries") {
+		t.Errorf("namedQuery(on-call) = %v, want an error pointing at plugins.jira.queries", err)
+	}
+}
+
+func TestFilterJQL(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/filter/10042":
+			fmt.Fprint(w, `{"id":"10042","name":"My open bugs","jql":"assignee = currentUser() AND resolution IS EMPTY ORDER BY priority DESC"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+	ctx := context.Background()
+
+	name, jql, err := p.filterJQL(ctx, "10042")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "My open bugs" || jql != "assignee = currentUser() AND resolution IS EMPTY ORDER BY priority DESC" {
+		t.Errorf("filterJQL(10042) = %q, %q; want the filter's name and JQL", name, jql)
+	}
+
+	name, jql, err = p.filterJQL(ctx, `Team "core" bugs`)
+	if err != nil || name != `Team "core" bugs` || jql != `filter = "Team \"core\" bugs"` {
+		t.Errorf("filterJQL by name = %q, %q, %v; want a quoted filter clause", name, jql, err)
+	}
+	if n := len(fake.seen()); n != 1 {
+		t.Errorf("made %d requests, want only the lookup by ID", n)
+	}
+
+	if _, _, err := p.filterJQL(ctx, "404"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("filterJQL(404) = %v, want the failed lookup", err)
+	}
+}
+
+func TestListIssuesQuotesProjectKey(t *testing.T) {
+	fake := &fakeJira{handle: func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total":0,"issues":[]}`)
+	}}
+	p := newJiraTestPlugin(t, "https://jira.acme.dev", fake)
+
+	if err := p.listIssues(context.Background(), `CS" OR project = "OPS`, 0); err != nil {
+		t.Fatal(err)
+	}
+	requests := fake.seen()
+	if len(requests) != 1 {
+		t.Fatalf("made %d requests, want 1", len(requests))
+	}
+	if got, want := requests[0].Query().Get("jql"), `project = "CS\" OR project = \"OPS"`; got != want {
+		t.Errorf("jql = %q, want %q", got, want)
+	}
+}
@@ -1,9 +1,12 @@
 package plugins
 
 import (
+	"context"
 	"fmt"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
 	"plugin"
+	"sort"
 )
 
 type PluginManager struct {
@@ -50,9 +53,17 @@ func (pm *PluginManager) LoadExternalPlugin(path string) error {
 		return fmt.Errorf("failed to find PluginInstance in %s: %w", path, err)
 	}
 
-	pluginInstance, ok := sym.(Plugin)
-	if !ok {
-		return fmt.Errorf("invalid plugin format in %s", path)
+	var pluginInstance Plugin
+	switch instance := sym.(type) {
+	case Plugin:
+		pluginInstance = instance
+	case LegacyPlugin:
+		logger.Logger.Warn().Str("plugin_path", path).Msg("Plugin uses the pre-context Execute(args) interface")
+		pluginInstance = legacyPlugin{instance}
+	default:
+		return fmt.Errorf("invalid plugin format in %s: PluginInstance must implement Init() error, "+
+			"Execute(ctx context.Context, args []string) error and Info() (string, string), "+
+			"or the older Execute(args []string) error", path)
 	}
 
 	name, desc := pluginInstance.Info()
@@ -62,15 +73,52 @@ func (pm *PluginManager) LoadExternalPlugin(path string) error {
 	return nil
 }
 
-func (pm *PluginManager) ExecutePlugin(name string, args []string) error {
+func (pm *PluginManager) ExecutePlugin(ctx context.Context, name string, args []string) error {
 	plugin, exists := pm.Plugins[name]
 	if !exists {
 		logger.Logger.Error().Str("plugin", name).Msg("❌ Plugin not found")
 		return fmt.Errorf("plugin not found: %s", name)
 	}
 
+	if err := plugin.Init(); err != nil {
+		return fmt.Errorf("failed to initialize plugin %s: %w", name, err)
+	}
+
 	logger.Logger.Info().Str("plugin", name).Msg("🚀 Executing plugin")
-	return plugin.Execute(args)
+	return plugin.Execute(ctx, args)
+}
+
+// Fetch initializes the named plugin and fetches contributions from it.
+// The plugin must implement Source.
+func (pm *PluginManager) Fetch(ctx context.Context, name string, q Query) ([]contrib.Contribution, error) {
+	plugin, exists := pm.Plugins[name]
+	if !exists {
+		return nil, fmt.Errorf("plugin not found: %s", name)
+	}
+
+	source, ok := plugin.(Source)
+	if !ok {
+		return nil, fmt.Errorf("plugin %s does not support fetching contributions", name)
+	}
+
+	if err := source.Init(); err != nil {
+		return nil, fmt.Errorf("failed to initialize plugin %s: %w", name, err)
+	}
+
+	logger.Logger.Info().Str("plugin", name).Msg("📥 Fetching contributions")
+	return source.Fetch(ctx, q)
+}
+
+// Sources returns the sorted names of loaded plugins that implement Source
+func (pm *PluginManager) Sources() []string {
+	var names []string
+	for name, p := range pm.Plugins {
+		if _, ok := p.(Source); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 func (pm *PluginManager) ListPlugins() {
@@ -1,11 +1,13 @@
 package plugins
 
 import (
+	"context"
 	"fmt"
 	"github.com/ibexmonj/ContribSync/pkg/logger"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 )
 
 // SlackPlugin allows sending messages to Slack
@@ -20,7 +22,7 @@ func (s *SlackPlugin) Info() (string, string) {
 	return "slack", "Slack Plugin: Send messages to Slack channels"
 }
 
-func (s *SlackPlugin) Execute(args []string) error {
+func (s *SlackPlugin) Execute(ctx context.Context, args []string) error {
 	if len(args) < 2 {
 		return fmt.Errorf("Usage: csync plugin exec slack send [channel] [message]")
 	}
@@ -28,26 +30,26 @@ func (s *SlackPlugin) Execute(args []string) error {
 	if args[0] == "send" {
 		channel := args[1]
 		message := strings.Join(args[2:], " ")
-		return sendSlackMessage(channel, message)
+		return sendSlackMessage(ctx, channel, message)
 	}
 	return fmt.Errorf("unknown Slack command: %s", args[0])
 }
 
-func sendSlackMessage(channel, message string) error {
+func sendSlackMessage(ctx context.Context, channel, message string) error {
 	slackWebhook := os.Getenv("SLACK_WEBHOOK_URL")
 	if slackWebhook == "" {
 		return fmt.Errorf("SLACK_WEBHOOK_URL is not set. Please export your Slack webhook URL.")
 	}
 
 	payload := fmt.Sprintf(`{"channel": "%s", "text": "%s"}`, channel, message)
-	req, err := http.NewRequest("POST", slackWebhook, strings.NewReader(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", slackWebhook, strings.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf("failed to create Slack request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{}
+	client := &http.Client{Timeout: 10 * time.Second}
 	resp, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send Slack message: %w", err)
@@ -0,0 +1,65 @@
+package prompt
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/report"
+)
+
+// funcs are available to every template, alongside text/template's built-ins
+var funcs = template.FuncMap{
+	// date formats a time as YYYY-MM-DD, or "" if it's zero
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format(time.DateOnly)
+	},
+	"join": func(items []string, sep string) string {
+		return strings.Join(items, sep)
+	},
+	// bySource keeps the contributions from one source: {{range bySource "jira" .Contributions}}
+	"bySource": func(source string, contributions []contrib.Contribution) []contrib.Contribution {
+		return filter(contributions, func(c contrib.Contribution) bool { return string(c.Source) == source })
+	},
+	// byKind keeps the contributions of one kind, e.g. pull_request or review
+	"byKind": func(kind string, contributions []contrib.Contribution) []contrib.Contribution {
+		return filter(contributions, func(c contrib.Contribution) bool { return string(c.Kind) == kind })
+	},
+	// meta returns a metadata value as text, or "" if it's unset: {{meta "epic" .}}
+	"meta": func(key string, c contrib.Contribution) string {
+		value, ok := c.Metadata[key]
+		if !ok || value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	},
+	// metaList returns a list-valued metadata entry such as credit or sprints
+	"metaList": func(key string, c contrib.Contribution) []string {
+		return c.MetadataStrings(key)
+	},
+	"points": report.StoryPoints,
+	"status": report.Status,
+	// truncate shortens text to n characters, e.g. long descriptions
+	"truncate": func(n int, text string) string {
+		runes := []rune(text)
+		if len(runes) <= n {
+			return text
+		}
+		return string(runes[:n]) + "…"
+	},
+}
+
+func filter(contributions []contrib.Contribution, keep func(contrib.Contribution) bool) []contrib.Contribution {
+	var kept []contrib.Contribution
+	for _, c := range contributions {
+		if keep(c) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
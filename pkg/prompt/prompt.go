@@ -0,0 +1,277 @@
+// Package prompt renders the text/template prompts sent to the language model.
+// Built-in templates can be overridden, or new ones added, by dropping
+// <name>.tmpl files into the prompts directory.
+package prompt
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/spf13/viper"
+)
+
+//go:embed templates/*.tmpl
+var builtins embed.FS
+
+// Extension of template files, both built-in and in the prompts directory
+const Extension = ".tmpl"
+
+// Built-in templates
+const (
+	SelfEvaluation = "self-evaluation"
+	// GroupSummary condenses one group of a contribution set too large for a
+	// single prompt; see pkg/summary
+	GroupSummary = "summarize-group"
+)
+
+// Data is what templates are executed with. When a contribution set is too
+// large for one prompt, it is summarized group by group and the final prompt
+// gets those Summaries instead of Contributions.
+type Data struct {
+	Contributions []contrib.Contribution
+	Summaries     []Partial
+	Group         string // The group being summarized, e.g. a repo, epic or month
+	Range         daterange.Range
+	User          Profile
+	Words         int // Target length of the answer
+}
+
+// Partial is the summary of one group of contributions
+type Partial struct {
+	Group string
+	Items int
+	Text  string
+}
+
+// Profile describes the person the contributions belong to
+type Profile struct {
+	Name          string
+	Role          string
+	Emails        []string
+	GitHubLogin   string
+	JiraAccountID string
+}
+
+// ProfileFromConfig builds the profile from the identity section of cfg
+func ProfileFromConfig(cfg *config.Config) Profile {
+	return Profile{
+		Name:          cfg.Identity.Name,
+		Role:          cfg.Identity.Role,
+		Emails:        cfg.Identity.Emails,
+		GitHubLogin:   cfg.Identity.GitHubLogin,
+		JiraAccountID: cfg.Identity.JiraAccountID,
+	}
+}
+
+// Prompt is a rendered template: the system prompt from its "system" block,
+// if it defines one, and the user prompt from the rest
+type Prompt struct {
+	System string
+	User   string
+}
+
+// Template is a parsed prompt template
+type Template struct {
+	Name        string
+	Description string // From a leading {{/* comment */}}
+	Path        string // The override file; empty for built-ins
+	Source      string
+	tmpl        *template.Template
+}
+
+// Builtin reports whether the template ships with csync rather than coming from the prompts directory
+func (t *Template) Builtin() bool {
+	return t.Path == ""
+}
+
+func (t *Template) Render(data Data) (Prompt, error) {
+	var p Prompt
+	if system := t.tmpl.Lookup("system"); system != nil {
+		var b strings.Builder
+		if err := system.Execute(&b, data); err != nil {
+			return Prompt{}, fmt.Errorf("prompt %s: %w", t.Name, err)
+		}
+		p.System = strings.TrimSpace(b.String())
+	}
+
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		return Prompt{}, fmt.Errorf("prompt %s: %w", t.Name, err)
+	}
+	p.User = strings.TrimSpace(b.String())
+	return p, nil
+}
+
+// Set is the built-in templates with any overrides applied
+type Set struct {
+	templates map[string]*Template
+}
+
+// Load parses the built-in templates and then every <name>.tmpl in dir, which
+// replace built-ins of the same name. A missing dir is fine. Each template is
+// test-rendered with sample data, so mistakes such as a misspelt field are
+// reported now rather than halfway through a summary.
+func Load(dir string) (*Set, error) {
+	s := &Set{templates: make(map[string]*Template)}
+
+	entries, err := fs.ReadDir(builtins, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		source, err := fs.ReadFile(builtins, "templates/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if err := s.add(strings.TrimSuffix(entry.Name(), Extension), "", string(source)); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir == "" {
+		return s, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Extension))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		source, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template: %w", err)
+		}
+		if err := s.add(strings.TrimSuffix(filepath.Base(path), Extension), path, string(source)); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// LoadFromConfig loads the templates with overrides from the configured prompts directory
+func LoadFromConfig() (*Set, error) {
+	return Load(Dir(&config.ConfigData))
+}
+
+// descriptionComment matches a template's leading {{/* description */}}
+var descriptionComment = regexp.MustCompile(`^\{\{-?\s*/\*\s*(.*?)\s*\*/\s*-?\}\}`)
+
+func (s *Set) add(name, path, source string) error {
+	where := name
+	if path != "" {
+		where = path
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Funcs(funcs).Parse(source)
+	if err != nil {
+		return fmt.Errorf("invalid prompt template %s: %w", where, err)
+	}
+
+	t := &Template{Name: name, Path: path, Source: source, tmpl: tmpl}
+	if m := descriptionComment.FindStringSubmatch(strings.TrimSpace(source)); m != nil {
+		t.Description = m[1]
+	}
+	if _, err := t.Render(sampleData); err != nil {
+		return fmt.Errorf("invalid prompt template %s: %w", where, errors.Unwrap(err))
+	}
+
+	s.templates[name] = t
+	return nil
+}
+
+// Get returns the named template
+func (s *Set) Get(name string) (*Template, error) {
+	t, ok := s.templates[name]
+	if !ok {
+		return nil, fmt.Errorf("no prompt template named %q; see `csync prompt list`", name)
+	}
+	return t, nil
+}
+
+// List returns the templates by name
+func (s *Set) List() []*Template {
+	list := make([]*Template, 0, len(s.templates))
+	for _, t := range s.templates {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Builtin returns the source of the named built-in template
+func Builtin(name string) (string, bool) {
+	source, err := fs.ReadFile(builtins, "templates/"+name+Extension)
+	if err != nil {
+		return "", false
+	}
+	return string(source), true
+}
+
+// Dir is the prompts directory: prompts.dir, or "prompts" next to config.yaml
+func Dir(cfg *config.Config) string {
+	if cfg.Prompts.Dir != "" {
+		return cfg.Prompts.Dir
+	}
+	if used := viper.ConfigFileUsed(); used != "" {
+		return filepath.Join(filepath.Dir(used), "prompts")
+	}
+	return "prompts"
+}
+
+// sampleData exercises the fields templates commonly use when validating them
+var sampleData = Data{
+	Contributions: []contrib.Contribution{
+		{
+			Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "CSYNC-1", Project: "CSYNC",
+			Title: "Sample issue", Status: "Done", URL: "https://example.atlassian.net/browse/CSYNC-1",
+			Authors:   []contrib.Identity{{Name: "Sample", Email: "sample@example.com"}},
+			UpdatedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+			Metadata:  map[string]any{"issuetype": "Story", "credit": []string{"moved to Done"}, "story_points": 3.0},
+		},
+		{
+			Source: contrib.SourceGitHub, Kind: contrib.KindPullRequest, ID: "1", Project: "owner/repo",
+			Title: "Sample PR", Status: "closed", URL: "https://github.com/owner/repo/pull/1",
+			UpdatedAt: time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC),
+			Metadata:  map[string]any{"merged": true},
+		},
+	},
+	Range:     daterange.Range{Since: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Until: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+	Summaries: []Partial{{Group: "owner/repo", Items: 2, Text: "Shipped the sample feature."}},
+	Group:     "owner/repo",
+	User:      Profile{Name: "Sample", Emails: []string{"sample@example.com"}},
+	Words:     300,
+}
+
+// Render renders data with the named template, loading templates from the configured prompts directory
+func Render(name string, data Data) (Prompt, error) {
+	set, err := LoadFromConfig()
+	if err != nil {
+		return Prompt{}, err
+	}
+	t, err := set.Get(name)
+	if err != nil {
+		return Prompt{}, err
+	}
+	return t.Render(data)
+}
+
+// Validate checks a single template file, as Load would
+func Validate(path string) error {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), Extension)
+	return (&Set{templates: make(map[string]*Template)}).add(name, path, string(source))
+}
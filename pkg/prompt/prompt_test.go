@@ -0,0 +1,148 @@
+package prompt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+func writeTemplate(t *testing.T, dir, name, source string) string {
+	t.Helper()
+	path := filepath.Join(dir, name+Extension)
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadRejectsMalformedOverride(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "syntax", source: "{{range .Contributions}}- {{.Title}}\n"},
+		{name: "misspelt field", source: "{{range .Contributons}}- {{.Title}}\n{{end}}"},
+		{name: "unknown function", source: "{{range .Contributions}}{{ticket .}}{{end}}"},
+		{name: "misspelt field in system", source: `{{define "system"}}You help {{.User.Nmae}}.{{end}}{{.Words}}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := writeTemplate(t, dir, SelfEvaluation, tc.source)
+
+			_, err := Load(dir)
+			if err == nil || !strings.Contains(err.Error(), path) {
+				t.Fatalf("Load = %v, want an error naming %s", err, path)
+			}
+			if err := Validate(path); err == nil {
+				t.Error("Validate accepted the template Load rejected")
+			}
+		})
+	}
+}
+
+func TestOverrideReplacesBuiltin(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTemplate(t, dir, SelfEvaluation, `{{/* Team flavoured */}}
+{{- define "system"}}You are terse.{{end}}
+{{range .Contributions}}{{.ID}} {{end}}`)
+	writeTemplate(t, dir, "release-notes", "{{range byKind \"pull_request\" .Contributions}}#{{.ID}} {{.Title}}\n{{end}}")
+
+	set, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := set.Get(SelfEvaluation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Builtin() || tmpl.Path != path || tmpl.Description != "Team flavoured" {
+		t.Errorf("template = %+v, want the override from %s", tmpl, path)
+	}
+	p, err := tmpl.Render(sampleData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.System != "You are terse." || p.User != "CSYNC-1 1" {
+		t.Errorf("rendered %+v, want the override's output", p)
+	}
+
+	added, err := set.Get("release-notes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err := added.Render(sampleData); err != nil || p.User != "#1 Sample PR" {
+		t.Errorf("release-notes rendered %+v, %v", p, err)
+	}
+
+	if other, _ := set.Get(GroupSummary); other == nil || !other.Builtin() {
+		t.Errorf("%s = %+v, want the built-in", GroupSummary, other)
+	}
+}
+
+func TestBuiltinsRender(t *testing.T) {
+	set, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	detailed := Data{
+		Contributions: []contrib.Contribution{
+			{
+				Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "OPS-7", Project: "OPS", Title: "Rotate certificates",
+				Status: "In Progress", UpdatedAt: since.AddDate(0, 0, 3),
+				Metadata: map[string]any{
+					"issuetype": "Task", "epic": "OPS-1", "story_points": 5.0,
+					"credit": []any{"assignee", "commented"}, "pull_requests": []string{"acme/api#12"},
+				},
+			},
+			{
+				Source: contrib.SourceGitHub, Kind: contrib.KindReview, ID: "12", Project: "acme/api", Title: "Rotate certs",
+				Status: "APPROVED", UpdatedAt: since.AddDate(0, 0, 4),
+				Metadata: map[string]any{"jira": []string{"OPS-7"}},
+			},
+		},
+		Range: daterange.Range{Since: since, Until: since.AddDate(0, 3, -1)},
+		User:  Profile{Name: "Dana", Role: "SRE"},
+		Words: 150,
+	}
+	grouped := Data{
+		Summaries: []Partial{{Group: "OPS", Items: 4, Text: "Rotated certificates."}},
+		Group:     "OPS",
+		Range:     detailed.Range,
+		Words:     150,
+	}
+
+	for _, tmpl := range set.List() {
+		if !tmpl.Builtin() {
+			t.Errorf("%s loaded from %s, want only built-ins", tmpl.Name, tmpl.Path)
+		}
+		for name, data := range map[string]Data{"sample": sampleData, "detailed": detailed, "grouped": grouped} {
+			p, err := tmpl.Render(data)
+			if err != nil {
+				t.Errorf("%s with %s data: %v", tmpl.Name, name, err)
+				continue
+			}
+			if p.System == "" || p.User == "" {
+				t.Errorf("%s with %s data rendered %+v, want both prompts", tmpl.Name, name, p)
+			}
+		}
+	}
+
+	p, err := set.templates[SelfEvaluation].Render(detailed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"as a SRE", "[Task] OPS-7: Rotate certificates", "delivered in acme/api#12", "for OPS-7", "no more than 150 words"} {
+		if !strings.Contains(p.User, want) {
+			t.Errorf("%s prompt lacks %q:\n%s", SelfEvaluation, want, p.User)
+		}
+	}
+}
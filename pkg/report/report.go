@@ -0,0 +1,138 @@
+package report
+
+import (
+	"sort"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+// Report groups contributions by source, then project, then status
+type Report struct {
+	Range   daterange.Range
+	Sources []SourceGroup
+	Total   int
+}
+
+type SourceGroup struct {
+	Source   contrib.Source
+	Projects []ProjectGroup
+	Count    int
+}
+
+type ProjectGroup struct {
+	Project  string
+	Statuses []StatusGroup
+	Count    int
+}
+
+type StatusGroup struct {
+	Status string
+	Items  []Item
+}
+
+// Item is a reported contribution. Commits that belong to a PR are folded
+// into it and counted rather than listed separately.
+type Item struct {
+	contrib.Contribution
+	Commits int
+}
+
+// Build groups contributions into a report. Ordering is deterministic:
+// sources, projects and statuses alphabetically, items newest first.
+func Build(r daterange.Range, contributions []contrib.Contribution) Report {
+	commitCounts := make(map[string]int)
+	for _, c := range contributions {
+		if c.Kind != contrib.KindCommit {
+			continue
+		}
+		for _, link := range c.Links {
+			if link.Rel == "pull_request" {
+				commitCounts[prKey(c.Source, c.Project, link.Target)]++
+			}
+		}
+	}
+
+	tree := make(map[contrib.Source]map[string]map[string][]Item)
+	total := 0
+	for _, c := range contributions {
+		if c.Kind == contrib.KindCommit && hasPullRequest(c) {
+			continue
+		}
+
+		if tree[c.Source] == nil {
+			tree[c.Source] = make(map[string]map[string][]Item)
+		}
+		if tree[c.Source][c.Project] == nil {
+			tree[c.Source][c.Project] = make(map[string][]Item)
+		}
+
+		status := Status(c)
+		item := Item{Contribution: c}
+		if c.Kind == contrib.KindPullRequest {
+			item.Commits = commitCounts[prKey(c.Source, c.Project, c.ID)]
+		}
+		tree[c.Source][c.Project][status] = append(tree[c.Source][c.Project][status], item)
+		total++
+	}
+
+	rep := Report{Range: r, Total: total}
+	for _, source := range sortedKeys(tree) {
+		sg := SourceGroup{Source: source}
+		for _, project := range sortedKeys(tree[source]) {
+			pg := ProjectGroup{Project: project}
+			for _, status := range sortedKeys(tree[source][project]) {
+				items := tree[source][project][status]
+				sort.SliceStable(items, func(i, j int) bool {
+					return items[i].UpdatedAt.After(items[j].UpdatedAt)
+				})
+				pg.Statuses = append(pg.Statuses, StatusGroup{Status: status, Items: items})
+				pg.Count += len(items)
+			}
+			sg.Projects = append(sg.Projects, pg)
+			sg.Count += pg.Count
+		}
+		rep.Sources = append(rep.Sources, sg)
+	}
+	return rep
+}
+
+// Status returns the status a contribution is reported under. Merged PRs are
+// reported as "merged" rather than GitHub's "closed".
+func Status(c contrib.Contribution) string {
+	if merged, _ := c.Metadata["merged"].(bool); merged {
+		return "merged"
+	}
+	if c.Status == "" {
+		switch c.Kind {
+		case contrib.KindCommit:
+			return "committed"
+		case contrib.KindComment, contrib.KindReviewComment:
+			return "commented"
+		}
+		return "unknown"
+	}
+	return c.Status
+}
+
+func hasPullRequest(c contrib.Contribution) bool {
+	for _, link := range c.Links {
+		if link.Rel == "pull_request" {
+			return true
+		}
+	}
+	return false
+}
+
+func prKey(source contrib.Source, project, id string) string {
+	return string(source) + "/" + project + "/" + id
+}
+
+func sortedKeys[K ~string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
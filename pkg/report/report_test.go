@@ -0,0 +1,115 @@
+package report
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 8, d, 12, 0, 0, 0, time.UTC)
+}
+
+func TestBuild(t *testing.T) {
+	pr := func(project, id, status string, updated int, merged bool) contrib.Contribution {
+		return contrib.Contribution{Source: contrib.SourceGitHub, Kind: contrib.KindPullRequest, Project: project, ID: id,
+			Status: status, UpdatedAt: day(updated), Metadata: map[string]any{"merged": merged}}
+	}
+	commit := func(project, sha string, updated int, links ...contrib.Link) contrib.Contribution {
+		return contrib.Contribution{Source: contrib.SourceGitHub, Kind: contrib.KindCommit, Project: project, ID: sha,
+			UpdatedAt: day(updated), Links: links}
+	}
+	inPR := func(n string) contrib.Link { return contrib.Link{Rel: "pull_request", Target: n} }
+
+	contributions := []contrib.Contribution{
+		{Source: contrib.SourceJira, Kind: contrib.KindIssue, Project: "CS", ID: "CS-1", Status: "Done", UpdatedAt: day(3)},
+		pr("acme/web", "4", "open", 2, false),
+		pr("acme/api", "1", "closed", 1, true),
+		commit("acme/api", "aaa", 1, inPR("1")),
+		commit("acme/api", "bbb", 1, inPR("1")),
+		commit("acme/web", "ccc", 1, inPR("1")), // Same number, another repo: counts for no PR in the report
+		pr("acme/api", "2", "closed", 5, true),
+		pr("acme/api", "3", "closed", 4, false),
+		commit("acme/api", "ddd", 6),
+		{Source: contrib.SourceGitHub, Kind: contrib.KindComment, Project: "acme/api", ID: "c1", UpdatedAt: day(7)},
+	}
+	rep := Build(daterange.Range{}, contributions)
+
+	if rep.Total != 7 {
+		t.Errorf("Total = %d, want 7 (commits in PRs folded)", rep.Total)
+	}
+
+	type row struct {
+		source  contrib.Source
+		project string
+		status  string
+		ids     []string
+	}
+	var got []row
+	counts := make(map[string]int)
+	commits := make(map[string]int)
+	for _, sg := range rep.Sources {
+		for _, pg := range sg.Projects {
+			counts[pg.Project] = pg.Count
+			for _, st := range pg.Statuses {
+				r := row{source: sg.Source, project: pg.Project, status: st.Status}
+				for _, item := range st.Items {
+					r.ids = append(r.ids, item.ID)
+					commits[pg.Project+"#"+item.ID] = item.Commits
+				}
+				got = append(got, r)
+			}
+		}
+		counts[string(sg.Source)] = sg.Count
+	}
+	want := []row{
+		{contrib.SourceGitHub, "acme/api", "closed", []string{"3"}},
+		{contrib.SourceGitHub, "acme/api", "commented", []string{"c1"}},
+		{contrib.SourceGitHub, "acme/api", "committed", []string{"ddd"}},
+		{contrib.SourceGitHub, "acme/api", "merged", []string{"2", "1"}}, // Newest first
+		{contrib.SourceGitHub, "acme/web", "open", []string{"4"}},
+		{contrib.SourceJira, "CS", "Done", []string{"CS-1"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d status groups %+v, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i].source != want[i].source || got[i].project != want[i].project || got[i].status != want[i].status || !slices.Equal(got[i].ids, want[i].ids) {
+			t.Errorf("group %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	for key, n := range map[string]int{"acme/api": 5, "acme/web": 1, "CS": 1, "github": 6, "jira": 1} {
+		if counts[key] != n {
+			t.Errorf("count of %s = %d, want %d", key, counts[key], n)
+		}
+	}
+	for key, n := range map[string]int{"acme/api#1": 2, "acme/api#2": 0, "acme/web#4": 0} {
+		if commits[key] != n {
+			t.Errorf("commits of %s = %d, want %d", key, commits[key], n)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		c    contrib.Contribution
+		want string
+	}{
+		{contrib.Contribution{Kind: contrib.KindPullRequest, Status: "closed", Metadata: map[string]any{"merged": true}}, "merged"},
+		{contrib.Contribution{Kind: contrib.KindPullRequest, Status: "closed", Metadata: map[string]any{"merged": false}}, "closed"},
+		{contrib.Contribution{Kind: contrib.KindCommit}, "committed"},
+		{contrib.Contribution{Kind: contrib.KindComment}, "commented"},
+		{contrib.Contribution{Kind: contrib.KindReviewComment}, "commented"},
+		{contrib.Contribution{Kind: contrib.KindReview, Status: "approved"}, "approved"},
+		{contrib.Contribution{Kind: contrib.KindIssue}, "unknown"},
+	}
+	for _, tc := range tests {
+		if got := Status(tc.c); got != tc.want {
+			t.Errorf("Status(%s %q) = %q, want %q", tc.c.Kind, tc.c.Status, got, tc.want)
+		}
+	}
+}
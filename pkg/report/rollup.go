@@ -0,0 +1,195 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+// Keys of the groups for issues without an epic or sprint
+const (
+	NoEpic   = "No epic"
+	NoSprint = "No sprint"
+)
+
+// Rollup totals the Jira issues completed in a range by epic, sprint and
+// calendar month
+type Rollup struct {
+	Range   daterange.Range
+	Epics   []RollupGroup
+	Sprints []RollupGroup
+	Periods []RollupGroup
+	Issues  int
+	Points  float64
+}
+
+// RollupGroup is the completed issues sharing an epic, sprint or month
+type RollupGroup struct {
+	Key    string
+	Title  string    // Epic summary, when known
+	End    time.Time // When the sprint ended
+	Items  []contrib.Contribution
+	Points float64
+}
+
+// BuildRollup groups the Jira issues resolved within r that the user delivered,
+// see Delivered. Epics and sprints come
+// from the epic, sprints and sprint_end metadata; an issue carried over
+// between sprints counts towards the last one. Epics are ordered by points,
+// sprints by end date and months chronologically, with the "No epic" and
+// "No sprint" groups last.
+func BuildRollup(r daterange.Range, contributions []contrib.Contribution) Rollup {
+	rollup := Rollup{Range: r}
+	epics := make(map[string]*RollupGroup)
+	sprints := make(map[string]*RollupGroup)
+	periods := make(map[string]*RollupGroup)
+
+	add := func(groups map[string]*RollupGroup, key string, c contrib.Contribution) *RollupGroup {
+		g, ok := groups[key]
+		if !ok {
+			g = &RollupGroup{Key: key}
+			groups[key] = g
+		}
+		g.Items = append(g.Items, c)
+		g.Points += StoryPoints(c)
+		return g
+	}
+
+	for _, c := range contributions {
+		if c.Source != contrib.SourceJira || !completedIn(c, r) || !Delivered(c) {
+			continue
+		}
+		rollup.Issues++
+		rollup.Points += StoryPoints(c)
+
+		epic, _ := c.Metadata["epic"].(string)
+		if issueType, _ := c.Metadata["issuetype"].(string); issueType == "Epic" {
+			epic = c.ID
+		}
+		if epic == "" {
+			epic = NoEpic
+		}
+		g := add(epics, epic, c)
+		if title, _ := c.Metadata["epic_title"].(string); title != "" {
+			g.Title = title
+		} else if epic == c.ID {
+			g.Title = c.Title
+		}
+
+		sprint := NoSprint
+		if names := c.MetadataStrings("sprints"); len(names) > 0 {
+			sprint = names[len(names)-1]
+		}
+		g = add(sprints, sprint, c)
+		sprintEnd, _ := c.Metadata["sprint_end"].(string)
+		if end, err := time.Parse(time.RFC3339, sprintEnd); err == nil {
+			g.End = end
+		}
+
+		add(periods, c.ClosedAt.Local().Format("2006-01"), c)
+	}
+
+	rollup.Epics = sortedGroups(epics, NoEpic, func(a, b *RollupGroup) bool {
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		return a.Key < b.Key
+	})
+	rollup.Sprints = sortedGroups(sprints, NoSprint, func(a, b *RollupGroup) bool {
+		if !a.End.Equal(b.End) {
+			return a.End.Before(b.End)
+		}
+		return a.Key < b.Key
+	})
+	rollup.Periods = sortedGroups(periods, "", func(a, b *RollupGroup) bool { return a.Key < b.Key })
+	return rollup
+}
+
+// EpicCount is the number of distinct epics with completed work
+func (r Rollup) EpicCount() int {
+	return countExcept(r.Epics, NoEpic)
+}
+
+// SprintCount is the number of sprints in which work was completed
+func (r Rollup) SprintCount() int {
+	return countExcept(r.Sprints, NoSprint)
+}
+
+// Summary is a one-line total, e.g. "Delivered 18 issues (42 points) across 3 epics over 6 sprints."
+func (r Rollup) Summary() string {
+	return fmt.Sprintf("Delivered %d issues (%s points) across %d epics over %d sprints.",
+		r.Issues, strconv.FormatFloat(r.Points, 'f', -1, 64), r.EpicCount(), r.SprintCount())
+}
+
+// StoryPoints returns a contribution's story_points metadata, or 0 if it has none
+func StoryPoints(c contrib.Contribution) float64 {
+	switch points := c.Metadata["story_points"].(type) {
+	case float64:
+		return points
+	case int:
+		return float64(points)
+	}
+	return 0
+}
+
+// Delivered reports whether the user finished a Jira issue: they moved it to
+// done or were its assignee when it was resolved. Issues without credit
+// metadata were selected by assignee, so they count too.
+func Delivered(c contrib.Contribution) bool {
+	if _, ok := c.Metadata["credit"]; !ok {
+		return true
+	}
+	for _, reason := range c.MetadataStrings("credit") {
+		if strings.HasPrefix(reason, contrib.CreditMovedTo) || reason == contrib.CreditAssigneeWhenResolved {
+			return true
+		}
+	}
+	return false
+}
+
+// completedIn reports whether c was resolved within r
+func completedIn(c contrib.Contribution, r daterange.Range) bool {
+	if c.ClosedAt.IsZero() {
+		return false
+	}
+	if !r.Since.IsZero() && c.ClosedAt.Before(r.Since) {
+		return false
+	}
+	return r.Until.IsZero() || !c.ClosedAt.After(r.Until)
+}
+
+// sortedGroups orders groups with less, putting the group keyed last at the end
+func sortedGroups(groups map[string]*RollupGroup, last string, less func(a, b *RollupGroup) bool) []RollupGroup {
+	sorted := make([]*RollupGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if (sorted[i].Key == last) != (sorted[j].Key == last) {
+			return sorted[j].Key == last
+		}
+		return less(sorted[i], sorted[j])
+	})
+
+	result := make([]RollupGroup, len(sorted))
+	for i, g := range sorted {
+		sort.SliceStable(g.Items, func(a, b int) bool { return g.Items[a].ClosedAt.Before(g.Items[b].ClosedAt) })
+		result[i] = *g
+	}
+	return result
+}
+
+func countExcept(groups []RollupGroup, key string) int {
+	n := 0
+	for _, g := range groups {
+		if g.Key != key {
+			n++
+		}
+	}
+	return n
+}
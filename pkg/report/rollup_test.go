@@ -0,0 +1,160 @@
+package report
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+)
+
+func TestBuildRollup(t *testing.T) {
+	issue := func(key string, closed time.Time, metadata map[string]any) contrib.Contribution {
+		return contrib.Contribution{Source: contrib.SourceJira, Kind: contrib.KindIssue, Project: "CS", ID: key,
+			Title: "Issue " + key, ClosedAt: closed, Metadata: metadata}
+	}
+	closed := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 12, 0, 0, 0, time.UTC)
+	}
+
+	contributions := []contrib.Contribution{
+		issue("CS-1", closed(8, 10), map[string]any{"epic": "CS-100", "epic_title": "Issue CS-100", "story_points": 5.0,
+			"sprints": []string{"Sprint 1", "Sprint 2"}, "sprint_end": "2026-08-14T00:00:00Z"}),
+		issue("CS-2", closed(7, 20), map[string]any{"epic": "CS-100", "story_points": 3,
+			"sprints": []string{"Sprint 1"}, "sprint_end": "2026-07-31T00:00:00Z"}),
+		issue("CS-3", closed(8, 20), map[string]any{"story_points": 2.0}),
+		issue("CS-100", closed(8, 25), map[string]any{"issuetype": "Epic",
+			"sprints": []string{"Sprint 2"}, "sprint_end": "2026-08-14T00:00:00Z"}),
+		issue("CS-4", closed(7, 5), map[string]any{"epic": "CS-200", "epic_title": "Auth", "story_points": 13.0,
+			"sprints": []string{"Sprint 0"}, "sprint_end": "2026-07-10T00:00:00Z"}),
+		issue("CS-5", time.Time{}, map[string]any{"epic": "CS-200", "story_points": 8.0}),  // Still open
+		issue("CS-6", closed(6, 1), map[string]any{"epic": "CS-200", "story_points": 8.0}), // Before the range
+		{Source: contrib.SourceGitHub, Kind: contrib.KindPullRequest, ID: "7", ClosedAt: closed(8, 1)},
+	}
+	r := daterange.Range{Since: closed(7, 1).Add(-12 * time.Hour), Until: closed(8, 31).Add(12 * time.Hour)}
+	rollup := BuildRollup(r, contributions)
+
+	if rollup.Issues != 5 || rollup.Points != 23 {
+		t.Errorf("totals = %d issues, %v points, want 5 and 23", rollup.Issues, rollup.Points)
+	}
+	if want := "Delivered 5 issues (23 points) across 2 epics over 3 sprints."; rollup.Summary() != want {
+		t.Errorf("Summary() = %q, want %q", rollup.Summary(), want)
+	}
+
+	type group struct {
+		key    string
+		title  string
+		points float64
+		ids    []string
+	}
+	check := func(name string, groups []RollupGroup, want []group) {
+		t.Helper()
+		if len(groups) != len(want) {
+			t.Errorf("%s: got %d groups, want %d", name, len(groups), len(want))
+			return
+		}
+		for i, g := range groups {
+			var ids []string
+			for _, item := range g.Items {
+				ids = append(ids, item.ID)
+			}
+			got := group{key: g.Key, title: g.Title, points: g.Points, ids: ids}
+			if got.key != want[i].key || got.title != want[i].title || got.points != want[i].points || !slices.Equal(got.ids, want[i].ids) {
+				t.Errorf("%s %d = %+v, want %+v", name, i, got, want[i])
+			}
+		}
+	}
+
+	// By points, items in the order they were completed
+	check("epic", rollup.Epics, []group{
+		{"CS-200", "Auth", 13, []string{"CS-4"}},
+		{"CS-100", "Issue CS-100", 8, []string{"CS-2", "CS-1", "CS-100"}},
+		{NoEpic, "", 2, []string{"CS-3"}},
+	})
+	// By end date; CS-1 counts towards the last sprint it was in
+	check("sprint", rollup.Sprints, []group{
+		{"Sprint 0", "", 13, []string{"CS-4"}},
+		{"Sprint 1", "", 3, []string{"CS-2"}},
+		{"Sprint 2", "", 5, []string{"CS-1", "CS-100"}},
+		{NoSprint, "", 2, []string{"CS-3"}},
+	})
+	check("month", rollup.Periods, []group{
+		{"2026-07", "", 16, []string{"CS-4", "CS-2"}},
+		{"2026-08", "", 7, []string{"CS-1", "CS-3", "CS-100"}},
+	})
+
+	if end := rollup.Sprints[2].End; !end.Equal(time.Date(2026, 8, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Sprint 2 ends %v, want 2026-08-14", end)
+	}
+}
+
+func TestBuildRollupEpicTitleFromEpicIssue(t *testing.T) {
+	contributions := []contrib.Contribution{
+		{Source: contrib.SourceJira, ID: "CS-100", Title: "Sync engine", ClosedAt: time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC),
+			Metadata: map[string]any{"issuetype": "Epic"}},
+	}
+	rollup := BuildRollup(daterange.Range{}, contributions)
+	if len(rollup.Epics) != 1 || rollup.Epics[0].Key != "CS-100" || rollup.Epics[0].Title != "Sync engine" {
+		t.Errorf("epics = %+v, want CS-100 titled from the epic itself", rollup.Epics)
+	}
+	if rollup.EpicCount() != 1 || rollup.SprintCount() != 0 {
+		t.Errorf("counts = %d epics, %d sprints, want 1 and 0", rollup.EpicCount(), rollup.SprintCount())
+	}
+}
+
+func TestBuildRollupCountsOnlyDeliveredIssues(t *testing.T) {
+	resolved := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	issue := func(key string, credit ...string) contrib.Contribution {
+		metadata := map[string]any{"story_points": 3.0}
+		if credit != nil {
+			metadata["credit"] = credit
+		}
+		return contrib.Contribution{Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: key, ClosedAt: resolved, Metadata: metadata}
+	}
+	// Read back from the store, credit is a []any
+	stored := issue("CS-5")
+	stored.Metadata["credit"] = []any{"commented (1)", contrib.CreditMovedTo + "Done"}
+
+	contributions := []contrib.Contribution{
+		issue("CS-1", contrib.CreditMovedTo+"Done"),
+		issue("CS-2", contrib.CreditAssigneeWhenResolved, "logged 2h"),
+		issue("CS-3", "commented (2)"),
+		issue("CS-4", "reported", "transitioned", "logged 1h"),
+		stored,
+		issue("CS-6"), // Fetched by assignee, without credit metadata
+	}
+	rollup := BuildRollup(daterange.Range{}, contributions)
+
+	var ids []string
+	for _, g := range rollup.Periods {
+		for _, item := range g.Items {
+			ids = append(ids, item.ID)
+		}
+	}
+	if rollup.Issues != 4 || rollup.Points != 12 || !slices.Equal(ids, []string{"CS-1", "CS-2", "CS-5", "CS-6"}) {
+		t.Errorf("rollup = %d issues, %v points, %v; want CS-1, CS-2, CS-5 and CS-6 for 12 points", rollup.Issues, rollup.Points, ids)
+	}
+}
+
+func TestStoryPoints(t *testing.T) {
+	tests := []struct {
+		points any
+		want   float64
+	}{
+		{5.0, 5},
+		{0.5, 0.5},
+		{3, 3},
+		{"8", 0},
+		{nil, 0},
+	}
+	for _, tc := range tests {
+		c := contrib.Contribution{Metadata: map[string]any{"story_points": tc.points}}
+		if got := StoryPoints(c); got != tc.want {
+			t.Errorf("StoryPoints(%#v) = %v, want %v", tc.points, got, tc.want)
+		}
+	}
+	if want := "Delivered 1 issues (0.5 points) across 0 epics over 0 sprints."; (Rollup{Issues: 1, Points: 0.5}).Summary() != want {
+		t.Errorf("Summary() = %q, want %q", (Rollup{Issues: 1, Points: 0.5}).Summary(), want)
+	}
+}
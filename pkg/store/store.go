@@ -0,0 +1,268 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+// SchemaVersion is the on-disk format version written by this build
+const SchemaVersion = 1
+
+const fileName = "contributions.jsonl"
+
+// header is the first line of the store file
+type header struct {
+	SchemaVersion int                  `json:"schema_version"`
+	SyncedAt      time.Time            `json:"synced_at"`
+	Cursors       map[string]time.Time `json:"cursors,omitempty"` // Keyed by source and target, see Cursor
+}
+
+// Store is a JSONL file of contributions keyed by contrib.Contribution.Key.
+// It is loaded fully into memory and rewritten atomically on Save.
+type Store struct {
+	path     string
+	header   header
+	records  map[string]contrib.Contribution
+	modified bool
+}
+
+// Stats summarizes the contents of a store
+type Stats struct {
+	Path          string
+	SchemaVersion int
+	SizeBytes     int64
+	SyncedAt      time.Time
+	Cursors       map[string]time.Time
+	Total         int
+	BySource      map[contrib.Source]int
+	ByKind        map[contrib.Kind]int
+	Oldest        time.Time
+	Newest        time.Time
+}
+
+// DefaultDir returns the directory csync keeps its data in:
+// $CSYNC_DATA_DIR, else $XDG_DATA_HOME/csync, else ~/.local/share/csync
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("CSYNC_DATA_DIR"); dir != "" {
+		return dir, nil
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "csync"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "csync"), nil
+}
+
+// Open loads the store in dir, creating an empty one if it does not exist yet
+func Open(dir string) (*Store, error) {
+	s := &Store{
+		path:    filepath.Join(dir, fileName),
+		header:  header{SchemaVersion: SchemaVersion},
+		records: make(map[string]contrib.Contribution),
+	}
+
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		if line == 1 {
+			if err := json.Unmarshal(scanner.Bytes(), &s.header); err != nil {
+				return nil, fmt.Errorf("invalid store header in %s: %w", s.path, err)
+			}
+			if s.header.SchemaVersion > SchemaVersion {
+				return nil, fmt.Errorf("store %s uses schema version %d, this csync supports up to %d; please upgrade",
+					s.path, s.header.SchemaVersion, SchemaVersion)
+			}
+			continue
+		}
+
+		var c contrib.Contribution
+		if err := json.Unmarshal(scanner.Bytes(), &c); err != nil {
+			return nil, fmt.Errorf("invalid record on line %d of %s: %w", line, s.path, err)
+		}
+		s.records[c.Key()] = c
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+
+	// Files written by older builds are upgraded the next time they are saved
+	if s.header.SchemaVersion < SchemaVersion {
+		s.header.SchemaVersion = SchemaVersion
+		s.modified = true
+	}
+	return s, nil
+}
+
+// Path returns the location of the store file
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Upsert inserts or replaces contributions, reporting how many were new
+// and how many replaced an existing record. A replaced record's Jira credit
+// reasons are merged into the new ones, as a fetch since the last sync only
+// credits the activity it saw.
+func (s *Store) Upsert(contributions ...contrib.Contribution) (added, updated int) {
+	for _, c := range contributions {
+		key := c.Key()
+		if existing, exists := s.records[key]; exists {
+			updated++
+			if stored := existing.MetadataStrings("credit"); len(stored) > 0 && c.Metadata["credit"] != nil {
+				c.Metadata = maps.Clone(c.Metadata)
+				c.Metadata["credit"] = contrib.MergeCredit(stored, c.MetadataStrings("credit"))
+			}
+		} else {
+			added++
+		}
+		s.records[key] = c
+	}
+	if len(contributions) > 0 {
+		s.modified = true
+	}
+	return added, updated
+}
+
+// MarkSynced records the time of the last successful sync
+func (s *Store) MarkSynced(t time.Time) {
+	s.header.SyncedAt = t
+	s.modified = true
+}
+
+// Cursor returns the newest update time seen for key during a previous sync,
+// or the zero time if key has never been synced
+func (s *Store) Cursor(key string) time.Time {
+	return s.header.Cursors[key]
+}
+
+// SetCursor advances the cursor for key to t. Older times are ignored so a
+// sync that returned nothing new never moves a cursor backwards.
+func (s *Store) SetCursor(key string, t time.Time) {
+	if t.IsZero() || !t.After(s.header.Cursors[key]) {
+		return
+	}
+	if s.header.Cursors == nil {
+		s.header.Cursors = make(map[string]time.Time)
+	}
+	s.header.Cursors[key] = t
+	s.modified = true
+}
+
+// All returns every stored contribution, most recently updated first
+func (s *Store) All() []contrib.Contribution {
+	all := make([]contrib.Contribution, 0, len(s.records))
+	for _, c := range s.records {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if !all[i].UpdatedAt.Equal(all[j].UpdatedAt) {
+			return all[i].UpdatedAt.After(all[j].UpdatedAt)
+		}
+		return all[i].Key() < all[j].Key()
+	})
+	return all
+}
+
+// Save writes the store back to disk if it changed, via a temp file and rename
+// so an interrupted write never leaves a truncated store behind
+func (s *Store) Save() error {
+	if !s.modified {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), fileName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp store file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(s.header); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write store header: %w", err)
+	}
+	for _, c := range s.All() {
+		if err := enc.Encode(c); err != nil {
+			tmp.Close()
+			return fmt.Errorf("failed to write contribution %s: %w", c.Key(), err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace store file: %w", err)
+	}
+	s.modified = false
+	return nil
+}
+
+// Stats summarizes what is in the store
+func (s *Store) Stats() Stats {
+	stats := Stats{
+		Path:          s.path,
+		SchemaVersion: s.header.SchemaVersion,
+		SyncedAt:      s.header.SyncedAt,
+		Cursors:       s.header.Cursors,
+		Total:         len(s.records),
+		BySource:      make(map[contrib.Source]int),
+		ByKind:        make(map[contrib.Kind]int),
+	}
+
+	if info, err := os.Stat(s.path); err == nil {
+		stats.SizeBytes = info.Size()
+	}
+
+	for _, c := range s.records {
+		stats.BySource[c.Source]++
+		stats.ByKind[c.Kind]++
+
+		if c.UpdatedAt.IsZero() {
+			continue
+		}
+		if stats.Oldest.IsZero() || c.UpdatedAt.Before(stats.Oldest) {
+			stats.Oldest = c.UpdatedAt
+		}
+		if c.UpdatedAt.After(stats.Newest) {
+			stats.Newest = c.UpdatedAt
+		}
+	}
+	return stats
+}
@@ -0,0 +1,239 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+func contribution(id string, updated time.Time) contrib.Contribution {
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindPullRequest,
+		ID:        id,
+		Project:   "acme/api",
+		Title:     "PR " + id,
+		UpdatedAt: updated,
+		Metadata:  map[string]any{"branch": "feature-" + id},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	synced := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	cursor := time.Date(2026, 8, 31, 9, 30, 0, 0, time.UTC)
+
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, updated := s.Upsert(contribution("1", cursor.Add(-time.Hour)), contribution("2", cursor))
+	if added != 2 || updated != 0 {
+		t.Fatalf("Upsert = %d added, %d updated; want 2, 0", added, updated)
+	}
+	s.SetCursor("github:acme/api", cursor)
+	s.MarkSynced(synced)
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := reopened.All()
+	if len(all) != 2 || all[0].ID != "2" || all[1].ID != "1" {
+		t.Fatalf("All() = %v, want PRs 2 then 1", all)
+	}
+	if got := all[0].Metadata["branch"]; got != "feature-2" {
+		t.Errorf("branch metadata = %v, want feature-2", got)
+	}
+	if got := reopened.Cursor("github:acme/api"); !got.Equal(cursor) {
+		t.Errorf("Cursor = %v, want %v", got, cursor)
+	}
+	if got := reopened.Stats().SyncedAt; !got.Equal(synced) {
+		t.Errorf("SyncedAt = %v, want %v", got, synced)
+	}
+
+	added, updated = reopened.Upsert(contribution("2", cursor.Add(time.Hour)))
+	if added != 0 || updated != 1 {
+		t.Errorf("re-Upsert = %d added, %d updated; want 0, 1", added, updated)
+	}
+}
+
+func TestSchemaVersion(t *testing.T) {
+	t.Run("newer", func(t *testing.T) {
+		dir := t.TempDir()
+		writeStore(t, dir, `{"schema_version":2}`)
+		if _, err := Open(dir); err == nil || !strings.Contains(err.Error(), "schema version 2") {
+			t.Fatalf("Open = %v, want a schema version error", err)
+		}
+	})
+
+	t.Run("older is upgraded on save", func(t *testing.T) {
+		dir := t.TempDir()
+		writeStore(t, dir, `{"schema_version":0}`+"\n"+`{"source":"jira","kind":"issue","id":"CS-1","project":"CS"}`)
+		s, err := Open(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Stats().SchemaVersion; got != SchemaVersion {
+			t.Fatalf("SchemaVersion = %d, want %d", got, SchemaVersion)
+		}
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(data), `{"schema_version":1,`) || !strings.Contains(string(data), `"CS-1"`) {
+			t.Errorf("saved store = %s", data)
+		}
+	})
+}
+
+func TestSetCursorOnlyAdvances(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer := time.Date(2026, 9, 2, 0, 0, 0, 0, time.UTC)
+	older := newer.Add(-24 * time.Hour)
+
+	for _, tc := range []struct {
+		set  time.Time
+		want time.Time
+	}{
+		{set: older, want: older},
+		{set: newer, want: newer},
+		{set: older, want: newer},
+		{set: time.Time{}, want: newer},
+		{set: newer, want: newer},
+	} {
+		s.SetCursor("jira", tc.set)
+		if got := s.Cursor("jira"); !got.Equal(tc.want) {
+			t.Fatalf("after SetCursor(%v): Cursor = %v, want %v", tc.set, got, tc.want)
+		}
+	}
+	if got := s.Cursor("github"); !got.IsZero() {
+		t.Errorf("unsynced cursor = %v, want zero", got)
+	}
+}
+
+func TestSaveIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Upsert(contribution("1", time.Now()))
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	assertNoTempFiles(t, dir)
+
+	// A failed rename must leave neither a temp file nor a partial store behind
+	path := filepath.Join(dir, fileName)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	s.Upsert(contribution("2", time.Now()))
+	if err := s.Save(); err == nil {
+		t.Fatal("Save over a directory succeeded, want an error")
+	}
+	assertNoTempFiles(t, dir)
+}
+
+func TestDefaultDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory:", err)
+	}
+
+	tests := []struct {
+		name    string
+		dataDir string
+		xdg     string
+		want    string
+	}{
+		{name: "CSYNC_DATA_DIR", dataDir: "/srv/csync", xdg: "/xdg", want: "/srv/csync"},
+		{name: "XDG_DATA_HOME", xdg: "/xdg", want: filepath.Join("/xdg", "csync")},
+		{name: "home", want: filepath.Join(home, ".local", "share", "csync")},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("CSYNC_DATA_DIR", tc.dataDir)
+			t.Setenv("XDG_DATA_HOME", tc.xdg)
+			got, err := DefaultDir()
+			if err != nil || got != tc.want {
+				t.Errorf("DefaultDir = %q, %v; want %q", got, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldest := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	newest := oldest.AddDate(0, 2, 0)
+	issue := contrib.Contribution{Source: contrib.SourceJira, Kind: contrib.KindIssue, ID: "CS-1", Project: "CS", UpdatedAt: newest}
+	undated := contribution("3", time.Time{})
+	s.Upsert(contribution("1", oldest), contribution("2", oldest.AddDate(0, 1, 0)), undated, issue)
+
+	stats := s.Stats()
+	if stats.Total != 4 || stats.BySource[contrib.SourceGitHub] != 3 || stats.BySource[contrib.SourceJira] != 1 {
+		t.Errorf("stats = %+v, want 3 GitHub and 1 Jira contributions", stats)
+	}
+	if stats.ByKind[contrib.KindPullRequest] != 3 || stats.ByKind[contrib.KindIssue] != 1 {
+		t.Errorf("ByKind = %v, want 3 PRs and 1 issue", stats.ByKind)
+	}
+	if !stats.Oldest.Equal(oldest) || !stats.Newest.Equal(newest) {
+		t.Errorf("range = %v to %v, want %v to %v ignoring the undated record", stats.Oldest, stats.Newest, oldest, newest)
+	}
+	if stats.SizeBytes != 0 {
+		t.Errorf("SizeBytes = %d before saving, want 0", stats.SizeBytes)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Stats().SizeBytes == 0 {
+		t.Error("SizeBytes = 0 after saving")
+	}
+}
+
+func TestOpenRejectsInvalidRecord(t *testing.T) {
+	dir := t.TempDir()
+	writeStore(t, dir, `{"schema_version":1}`+"\n"+`{"source":"jira","kind":"issue","id":"CS-1"}`+"\n"+`{"source":`)
+	if _, err := Open(dir); err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Fatalf("Open = %v, want an error naming line 3", err)
+	}
+}
+
+func writeStore(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, fileName), []byte(content+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	tmp, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmp) > 0 {
+		t.Errorf("temp files left behind: %v", tmp)
+	}
+}
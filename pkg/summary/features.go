@@ -0,0 +1,133 @@
+package summary
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+// jiraKeyPattern matches issue keys such as CSYNC-123, including lower-case
+// ones in branch names like feature/csync-123-retry
+var jiraKeyPattern = regexp.MustCompile(`(?i)\b([a-z][a-z0-9_]+-\d+)\b`)
+
+// JiraKeys returns the Jira keys mentioned in a PR or commit's title and
+// branch. Only keys in projects count, so "utf-8" isn't mistaken for one.
+func JiraKeys(c contrib.Contribution, projects map[string]bool) []string {
+	branch, _ := c.Metadata["branch"].(string)
+
+	var keys []string
+	seen := make(map[string]bool)
+	for _, match := range jiraKeyPattern.FindAllStringSubmatch(c.Title+" "+branch, -1) {
+		key := strings.ToUpper(match[1])
+		project := key[:strings.LastIndex(key, "-")]
+		if projects[project] && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// LinkFeatures prepares a mixed GitHub and Jira set for summarizing, so the
+// narrative can describe features instead of listing tickets and PRs
+// separately. Each PR that mentions a Jira issue in the set is folded into
+// that issue, listed in its pull_requests metadata; PRs mentioning other keys
+// keep them in their jira metadata. Commits that belong to a PR are left out,
+// as the PR stands for them. projects adds Jira project keys to those of the
+// issues in the set. The input is not modified.
+func LinkFeatures(contributions []contrib.Contribution, projects []string) []contrib.Contribution {
+	known := make(map[string]bool)
+	for _, project := range projects {
+		known[strings.ToUpper(project)] = true
+	}
+	issues := make(map[string]int) // Issue key → index in linked
+	var linked []contrib.Contribution
+	for _, c := range contributions {
+		if c.Source == contrib.SourceJira && c.Kind == contrib.KindIssue {
+			known[strings.ToUpper(c.Project)] = true
+			c.Metadata = maps.Clone(c.Metadata)
+			if c.Metadata == nil {
+				c.Metadata = make(map[string]any)
+			}
+			issues[c.ID] = len(linked)
+			linked = append(linked, c)
+		}
+	}
+
+	for _, c := range contributions {
+		switch {
+		case c.Source == contrib.SourceJira && c.Kind == contrib.KindIssue:
+			continue
+		case c.Kind == contrib.KindCommit && hasLink(c, "pull_request"):
+			continue
+		case c.Kind != contrib.KindPullRequest && c.Kind != contrib.KindCommit:
+			linked = append(linked, c)
+			continue
+		}
+
+		keys := JiraKeys(c, known)
+		if len(keys) == 0 {
+			linked = append(linked, c)
+			continue
+		}
+
+		c.Metadata = maps.Clone(c.Metadata)
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]any)
+		}
+		c.Metadata["jira"] = keys
+		c.Links = append(c.Links[:len(c.Links):len(c.Links)], jiraLinks(keys, linked, issues)...)
+
+		i, ok := firstIssue(keys, issues)
+		if !ok {
+			linked = append(linked, c)
+			continue
+		}
+		issue := &linked[i]
+		ref := c.Project + "#" + c.ID
+		if c.Kind == contrib.KindCommit {
+			ref = c.Project + "@" + shortSHA(c.ID)
+		}
+		issue.Metadata["pull_requests"] = append(issue.MetadataStrings("pull_requests"), ref+" "+c.Title)
+		issue.Links = append(issue.Links[:len(issue.Links):len(issue.Links)], contrib.Link{Rel: string(c.Kind), Target: ref, URL: c.URL})
+	}
+	return linked
+}
+
+func jiraLinks(keys []string, linked []contrib.Contribution, issues map[string]int) []contrib.Link {
+	links := make([]contrib.Link, len(keys))
+	for i, key := range keys {
+		links[i] = contrib.Link{Rel: "jira", Target: key}
+		if j, ok := issues[key]; ok {
+			links[i].URL = linked[j].URL
+		}
+	}
+	return links
+}
+
+func firstIssue(keys []string, issues map[string]int) (int, bool) {
+	for _, key := range keys {
+		if i, ok := issues[key]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func hasLink(c contrib.Contribution, rel string) bool {
+	for _, link := range c.Links {
+		if link.Rel == rel {
+			return true
+		}
+	}
+	return false
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,103 @@
+package summary
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+)
+
+func TestJiraKeys(t *testing.T) {
+	projects := map[string]bool{"CSYNC": true, "OPS": true}
+	tests := []struct {
+		name   string
+		title  string
+		branch string
+		want   []string
+	}{
+		{"title", "CSYNC-12: retry uploads", "", []string{"CSYNC-12"}},
+		{"lower-case branch", "Retry uploads", "feature/csync-12-retry", []string{"CSYNC-12"}},
+		{"title and branch once", "CSYNC-12 retry", "csync-12-retry", []string{"CSYNC-12"}},
+		{"several in order", "OPS-3 and CSYNC-12, CSYNC-4", "", []string{"OPS-3", "CSYNC-12", "CSYNC-4"}},
+		{"unknown project", "Decode utf-8 and ABC-1", "", nil},
+		{"inside a word", "CSYNC-12x and xCSYNC-13", "", nil},
+		{"no keys", "Bump dependencies", "main", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := contrib.Contribution{Title: tc.title}
+			if tc.branch != "" {
+				c.Metadata = map[string]any{"branch": tc.branch}
+			}
+			if got := JiraKeys(c, projects); !slices.Equal(got, tc.want) {
+				t.Errorf("JiraKeys(%q, %q) = %q, want %q", tc.title, tc.branch, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinkFeatures(t *testing.T) {
+	issue := func(key string, metadata map[string]any) contrib.Contribution {
+		return contrib.Contribution{Source: contrib.SourceJira, Kind: contrib.KindIssue, Project: "CS", ID: key,
+			Title: "Issue " + key, URL: "https://jira.example.com/browse/" + key, Metadata: metadata}
+	}
+	github := func(kind contrib.Kind, id, title string, links ...contrib.Link) contrib.Contribution {
+		return contrib.Contribution{Source: contrib.SourceGitHub, Kind: kind, Project: "acme/app", ID: id,
+			Title: title, URL: "https://github.com/acme/app/" + id, Links: links}
+	}
+
+	input := []contrib.Contribution{
+		github(contrib.KindPullRequest, "7", "CS-1: retry uploads"),
+		issue("CS-1", map[string]any{"epic": "CS-100"}),
+		github(contrib.KindPullRequest, "8", "OPS-5 rotate keys"),
+		github(contrib.KindCommit, "0123456789abcdef", "Fix CS-2 typo"),
+		github(contrib.KindCommit, "fedcba9876543210", "CS-1 part of #7", contrib.Link{Rel: "pull_request", Target: "acme/app#7"}),
+		github(contrib.KindPullRequest, "9", "Bump dependencies"),
+		github(contrib.KindReview, "7-review", "Review of CS-2 fix"),
+		issue("CS-2", nil),
+	}
+	original := slices.Clone(input)
+
+	linked := LinkFeatures(input, []string{"ops"})
+
+	var keys []string
+	for _, c := range linked {
+		keys = append(keys, c.ID)
+	}
+	// Issues first; PR 7 and the commits fold into them, the PR's own commit is dropped
+	want := []string{"CS-1", "CS-2", "8", "9", "7-review"}
+	if !slices.Equal(keys, want) {
+		t.Fatalf("linked = %v, want %v", keys, want)
+	}
+
+	cs1, cs2, ops, plain := linked[0], linked[1], linked[2], linked[3]
+	if got := cs1.MetadataStrings("pull_requests"); !slices.Equal(got, []string{"acme/app#7 CS-1: retry uploads"}) {
+		t.Errorf("CS-1 pull_requests = %q", got)
+	}
+	if cs1.Metadata["epic"] != "CS-100" {
+		t.Errorf("CS-1 lost its metadata: %v", cs1.Metadata)
+	}
+	if want := []contrib.Link{{Rel: "pull_request", Target: "acme/app#7", URL: "https://github.com/acme/app/7"}}; !slices.Equal(cs1.Links, want) {
+		t.Errorf("CS-1 links = %+v, want %+v", cs1.Links, want)
+	}
+	if got := cs2.MetadataStrings("pull_requests"); !slices.Equal(got, []string{"acme/app@0123456 Fix CS-2 typo"}) {
+		t.Errorf("CS-2 pull_requests = %q", got)
+	}
+
+	if got := ops.MetadataStrings("jira"); !slices.Equal(got, []string{"OPS-5"}) {
+		t.Errorf("PR 8 jira = %q, want OPS-5", got)
+	}
+	if want := []contrib.Link{{Rel: "jira", Target: "OPS-5"}}; !slices.Equal(ops.Links, want) {
+		t.Errorf("PR 8 links = %+v, want %+v", ops.Links, want)
+	}
+	if plain.Metadata != nil || plain.Links != nil {
+		t.Errorf("PR 9 was changed: %+v", plain)
+	}
+
+	// The input is left as it was
+	for i := range input {
+		if input[i].Metadata["pull_requests"] != nil || input[i].Metadata["jira"] != nil || len(input[i].Links) != len(original[i].Links) {
+			t.Errorf("input %s was modified: %+v", input[i].ID, input[i])
+		}
+	}
+}
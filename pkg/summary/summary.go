@@ -0,0 +1,421 @@
+// Package summary turns contribution sets of any size into a single AI
+// summary. Sets that fit in one prompt are summarized directly; larger ones
+// are map-reduced: each group of items (a repo, epic or month) is condensed
+// into a short note, and the notes are merged into the final narrative.
+package summary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/config"
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/llm"
+	"github.com/ibexmonj/ContribSync/pkg/logger"
+	"github.com/ibexmonj/ContribSync/pkg/prompt"
+)
+
+// Ways of grouping items for the map step
+const (
+	GroupByProject = "project" // Repo or Jira project
+	GroupByEpic    = "epic"
+	GroupByMonth   = "month"
+)
+
+// Options control how large sets are split and how long the answers are
+type Options struct {
+	Template      string // Final prompt template, e.g. prompt.SelfEvaluation
+	GroupBy       string
+	ContextTokens int // Largest prompt sent in one request
+	MaxTokens     int // Length of the final summary
+	GroupTokens   int // Length of each group's note
+	MaxRequests   int // Cap on map requests; items beyond it are dropped. 0 for no cap.
+}
+
+// OptionsFromConfig reads the summary and llm sections of cfg
+func OptionsFromConfig(cfg *config.Config) Options {
+	return Options{
+		Template:      prompt.SelfEvaluation,
+		GroupBy:       cfg.Summary.GroupBy,
+		ContextTokens: cfg.Summary.ContextTokens,
+		MaxTokens:     cfg.LLM.MaxTokens,
+		GroupTokens:   cfg.Summary.GroupTokens,
+		MaxRequests:   cfg.Summary.MaxRequests,
+	}
+}
+
+// Result is a summary and how it was produced
+type Result struct {
+	Text      string
+	Included  int  // Items that made it into the summary
+	Dropped   int  // Items left out to stay within the limits
+	Groups    int  // Group notes written; 0 when everything fit in one prompt
+	Requests  int  // Model calls made
+	Truncated bool // The final summary hit MaxTokens
+}
+
+// Coverage describes what the summary is based on, e.g.
+// "Based on 80 of 120 items (40 dropped to stay within summary limits), summarized in 8 groups; 9 requests."
+func (r *Result) Coverage() string {
+	line := fmt.Sprintf("Based on %d of %d items", r.Included, r.Included+r.Dropped)
+	if r.Dropped > 0 {
+		line += fmt.Sprintf(" (%d dropped to stay within summary limits)", r.Dropped)
+	}
+	if r.Groups > 0 {
+		line += fmt.Sprintf(", summarized in %d groups", r.Groups)
+	}
+	if r.Requests == 1 {
+		return line + "; 1 model request."
+	}
+	return line + fmt.Sprintf("; %d model requests.", r.Requests)
+}
+
+type Summarizer struct {
+	provider llm.Provider
+	prompts  *prompt.Set
+	opts     Options
+}
+
+func New(provider llm.Provider, prompts *prompt.Set, opts Options) *Summarizer {
+	return &Summarizer{provider: provider, prompts: prompts, opts: opts}
+}
+
+// FromConfig returns a summarizer using the configured provider, prompt templates and options
+func FromConfig() (*Summarizer, error) {
+	provider, err := llm.FromConfig()
+	if err != nil {
+		return nil, err
+	}
+	prompts, err := prompt.LoadFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	return New(provider, prompts, OptionsFromConfig(&config.ConfigData)), nil
+}
+
+// Summarize writes the summary of contributions in r for user
+func (s *Summarizer) Summarize(ctx context.Context, contributions []contrib.Contribution, r daterange.Range, user prompt.Profile) (*Result, error) {
+	final, err := s.prompts.Get(s.opts.Template)
+	if err != nil {
+		return nil, err
+	}
+	group, err := s.prompts.Get(prompt.GroupSummary)
+	if err != nil {
+		return nil, err
+	}
+
+	base := prompt.Data{Range: r, User: user, Words: Words(s.opts.MaxTokens)}
+	result := &Result{}
+
+	data := base
+	data.Contributions = contributions
+	p, err := final.Render(data)
+	if err != nil {
+		return nil, err
+	}
+	if s.fits(p) {
+		result.Included = len(contributions)
+		return result, s.complete(ctx, p, s.opts.MaxTokens, result)
+	}
+
+	if err := usesSummaries(final, base); err != nil {
+		return nil, err
+	}
+
+	// Map: condense each chunk of each group into a note
+	chunks, dropped, err := s.chunk(contributions, group, base)
+	if err != nil {
+		return nil, err
+	}
+	result.Dropped = dropped
+	if s.opts.MaxRequests > 0 && len(chunks) > s.opts.MaxRequests {
+		logger.Logger.Warn().Int("groups", len(chunks)).Int("max_requests", s.opts.MaxRequests).Msg("Too many groups to summarize; dropping the oldest work")
+		var kept int
+		chunks, kept = newestChunks(chunks, s.opts.MaxRequests)
+		result.Dropped += len(contributions) - dropped - kept
+	}
+
+	logger.Logger.Info().Int("items", len(contributions)).Int("groups", len(chunks)).Str("provider", s.provider.Name()).Msg("Summarizing in groups")
+	partials := make([]prompt.Partial, 0, len(chunks))
+	for _, c := range chunks {
+		data := base
+		data.Group, data.Contributions, data.Words = c.group, c.items, Words(s.opts.GroupTokens)
+		p, err := group.Render(data)
+		if err != nil {
+			return nil, err
+		}
+		note := &Result{}
+		if err := s.complete(ctx, p, s.opts.GroupTokens, note); err != nil {
+			return nil, fmt.Errorf("failed to summarize %s: %w", c.group, err)
+		}
+		result.Requests += note.Requests
+		result.Included += len(c.items)
+		partials = append(partials, prompt.Partial{Group: c.group, Items: len(c.items), Text: note.Text})
+	}
+	result.Groups = len(partials)
+
+	// Reduce: merge notes until the final prompt fits, then write the summary
+	for {
+		data := base
+		data.Summaries = partials
+		p, err := final.Render(data)
+		if err != nil {
+			return nil, err
+		}
+		if s.fits(p) || len(partials) == 1 {
+			return result, s.complete(ctx, p, s.opts.MaxTokens, result)
+		}
+		if partials, err = s.merge(ctx, partials, group, base, result); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// chunk is the items summarized in one map request
+type chunk struct {
+	group string
+	items []contrib.Contribution
+}
+
+// chunk groups contributions and splits each group into chunks whose prompt
+// fits in ContextTokens. Items too large to fit even alone are dropped.
+func (s *Summarizer) chunk(contributions []contrib.Contribution, tmpl *prompt.Template, base prompt.Data) ([]chunk, int, error) {
+	data := base
+	data.Words = Words(s.opts.GroupTokens)
+	overhead, err := s.tokens(tmpl, data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var chunks []chunk
+	dropped := 0
+	for _, g := range groupItems(contributions, s.opts.GroupBy) {
+		data.Group = g.group
+		current := chunk{group: g.group}
+		used := overhead
+		for _, item := range g.items {
+			data.Contributions = []contrib.Contribution{item}
+			withItem, err := s.tokens(tmpl, data)
+			if err != nil {
+				return nil, 0, err
+			}
+			cost := withItem - overhead
+			if overhead+cost > s.budget() {
+				dropped++
+				logger.Logger.Warn().Str("item", item.Key()).Msg("Contribution too large to summarize; dropped")
+				continue
+			}
+			if used+cost > s.budget() && len(current.items) > 0 {
+				chunks = append(chunks, current)
+				current, used = chunk{group: g.group}, overhead
+			}
+			current.items = append(current.items, item)
+			used += cost
+		}
+		if len(current.items) > 0 {
+			chunks = append(chunks, current)
+		}
+	}
+
+	// Number the parts of groups that needed more than one chunk
+	counts := make(map[string]int)
+	for _, c := range chunks {
+		counts[c.group]++
+	}
+	seen := make(map[string]int)
+	for i, c := range chunks {
+		if counts[c.group] > 1 {
+			seen[c.group]++
+			chunks[i].group = fmt.Sprintf("%s (part %d of %d)", c.group, seen[c.group], counts[c.group])
+		}
+	}
+	return chunks, dropped, nil
+}
+
+// newestChunks keeps the n chunks with the most recent work, in their
+// original order, and returns how many items they hold. Groups are chunked
+// newest first, so every group keeps its latest part for as long as possible.
+func newestChunks(chunks []chunk, n int) ([]chunk, int) {
+	newest := func(c chunk) time.Time {
+		var t time.Time
+		for _, item := range c.items {
+			if item.UpdatedAt.After(t) {
+				t = item.UpdatedAt
+			}
+		}
+		return t
+	}
+
+	order := make([]int, len(chunks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return newest(chunks[order[a]]).After(newest(chunks[order[b]])) })
+	keep := order[:n]
+	sort.Ints(keep)
+
+	kept, items := make([]chunk, 0, n), 0
+	for _, i := range keep {
+		kept = append(kept, chunks[i])
+		items += len(chunks[i].items)
+	}
+	return kept, items
+}
+
+// merge condenses runs of notes into single notes, as many per request as
+// fit, so that the final prompt eventually does
+func (s *Summarizer) merge(ctx context.Context, partials []prompt.Partial, tmpl *prompt.Template, base prompt.Data, result *Result) ([]prompt.Partial, error) {
+	var merged []prompt.Partial
+	for start := 0; start < len(partials); {
+		// Take as many notes as fit in one request, and at least two so this terminates
+		end := start + 1
+		for end < len(partials) {
+			data := base
+			data.Summaries, data.Words = partials[start:end+1], Words(s.opts.GroupTokens)
+			p, err := tmpl.Render(data)
+			if err != nil {
+				return nil, err
+			}
+			if !s.fits(p) && end-start >= 2 {
+				break
+			}
+			end++
+		}
+
+		batch := partials[start:end]
+		if len(batch) == 1 {
+			merged = append(merged, batch[0])
+			start = end
+			continue
+		}
+
+		data := base
+		data.Summaries, data.Words = batch, Words(s.opts.GroupTokens)
+		data.Group = fmt.Sprintf("%s … %s", batch[0].Group, batch[len(batch)-1].Group)
+		p, err := tmpl.Render(data)
+		if err != nil {
+			return nil, err
+		}
+		note := &Result{}
+		if err := s.complete(ctx, p, s.opts.GroupTokens, note); err != nil {
+			return nil, fmt.Errorf("failed to merge summaries: %w", err)
+		}
+		result.Requests += note.Requests
+
+		items := 0
+		for _, partial := range batch {
+			items += partial.Items
+		}
+		merged = append(merged, prompt.Partial{Group: data.Group, Items: items, Text: note.Text})
+		start = end
+	}
+	return merged, nil
+}
+
+// usesSummaries checks that a final template can take group notes, before any are written
+func usesSummaries(tmpl *prompt.Template, base prompt.Data) error {
+	without, err := tmpl.Render(base)
+	if err != nil {
+		return err
+	}
+	data := base
+	data.Summaries = []prompt.Partial{{Group: "group", Items: 1, Text: "note"}}
+	with, err := tmpl.Render(data)
+	if err != nil {
+		return err
+	}
+	if with == without {
+		return fmt.Errorf("prompt %s doesn't use .Summaries, which contribution sets too large for one prompt need; see `csync prompt show %s`", tmpl.Name, prompt.SelfEvaluation)
+	}
+	return nil
+}
+
+func (s *Summarizer) complete(ctx context.Context, p prompt.Prompt, maxTokens int, result *Result) error {
+	resp, err := s.provider.Complete(ctx, llm.Request{Messages: llm.Prompt(p.System, p.User), MaxTokens: maxTokens})
+	if err != nil {
+		return err
+	}
+	result.Requests++
+	result.Text = resp.Text
+	result.Truncated = resp.Truncated
+	return nil
+}
+
+func (s *Summarizer) fits(p prompt.Prompt) bool {
+	return EstimateTokens(p.System)+EstimateTokens(p.User) <= s.budget()
+}
+
+// budget is the prompt size allowed per request
+func (s *Summarizer) budget() int {
+	if s.opts.ContextTokens <= 0 {
+		return 8000
+	}
+	return s.opts.ContextTokens
+}
+
+func (s *Summarizer) tokens(tmpl *prompt.Template, data prompt.Data) (int, error) {
+	p, err := tmpl.Render(data)
+	if err != nil {
+		return 0, err
+	}
+	return EstimateTokens(p.System) + EstimateTokens(p.User), nil
+}
+
+// EstimateTokens approximates how many tokens text is, at about four
+// characters a token. It errs high for English so prompts stay under budget.
+func EstimateTokens(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
+
+// Words turns a token limit into the word count asked for in prompts,
+// leaving room so answers end before the limit cuts them off
+func Words(tokens int) int {
+	return tokens * 6 / 10
+}
+
+type itemGroup struct {
+	group string
+	items []contrib.Contribution
+}
+
+// groupItems groups contributions by project, epic or month. Groups are in
+// chronological order for months and alphabetical otherwise; items are newest first.
+func groupItems(contributions []contrib.Contribution, by string) []itemGroup {
+	key := func(c contrib.Contribution) string {
+		switch by {
+		case GroupByEpic:
+			if epic, _ := c.Metadata["epic"].(string); epic != "" {
+				if title, _ := c.Metadata["epic_title"].(string); title != "" {
+					return epic + " " + title
+				}
+				return epic
+			}
+			return "No epic"
+		case GroupByMonth:
+			return c.UpdatedAt.Local().Format("2006-01")
+		default:
+			return string(c.Source) + " " + c.Project
+		}
+	}
+
+	index := make(map[string]int)
+	var groups []itemGroup
+	for _, c := range contributions {
+		k := key(c)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, itemGroup{group: k})
+		}
+		groups[i].items = append(groups[i].items, c)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].group < groups[j].group })
+	for _, g := range groups {
+		sort.SliceStable(g.items, func(i, j int) bool { return g.items[i].UpdatedAt.After(g.items[j].UpdatedAt) })
+	}
+	return groups
+}
@@ -0,0 +1,220 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibexmonj/ContribSync/pkg/contrib"
+	"github.com/ibexmonj/ContribSync/pkg/daterange"
+	"github.com/ibexmonj/ContribSync/pkg/llm"
+	"github.com/ibexmonj/ContribSync/pkg/prompt"
+)
+
+// fakeProvider answers every request with a numbered note and records the prompts
+type fakeProvider struct {
+	prompts []string
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Complete(_ context.Context, req llm.Request) (*llm.Response, error) {
+	user := req.Messages[len(req.Messages)-1].Content
+	f.prompts = append(f.prompts, user)
+	return &llm.Response{Text: fmt.Sprintf("note %d", len(f.prompts))}, nil
+}
+
+// testPrompts replaces the built-in templates with minimal ones, so token
+// counts depend only on the items: each costs about a quarter of its title's length
+func testPrompts(t *testing.T) *prompt.Set {
+	t.Helper()
+	dir := t.TempDir()
+	templates := map[string]string{
+		prompt.SelfEvaluation: `final{{range .Summaries}}|{{.Group}}:{{.Text}}{{end}}{{range .Contributions}}|{{.Title}}{{end}}`,
+		prompt.GroupSummary:   `{{.Group}}{{range .Summaries}}|{{.Text}}{{end}}{{range .Contributions}}|{{.Title}}{{end}}`,
+	}
+	for name, source := range templates {
+		if err := os.WriteFile(filepath.Join(dir, name+prompt.Extension), []byte(source), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prompts, err := prompt.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return prompts
+}
+
+// item is a GitHub PR in project updated on day of August 2026, whose title is size characters long
+func item(project, id string, day, size int) contrib.Contribution {
+	return contrib.Contribution{
+		Source:    contrib.SourceGitHub,
+		Kind:      contrib.KindPullRequest,
+		Project:   project,
+		ID:        id,
+		Title:     id + strings.Repeat(".", size-len(id)),
+		UpdatedAt: time.Date(2026, 8, day, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func ids(items []contrib.Contribution) []string {
+	var out []string
+	for _, c := range items {
+		out = append(out, c.ID)
+	}
+	return out
+}
+
+// With a budget of 60 tokens, two 100-character items fit in a chunk and a 300-character item fits nowhere
+func TestChunk(t *testing.T) {
+	prompts := testPrompts(t)
+	group, _ := prompts.Get(prompt.GroupSummary)
+	s := New(&fakeProvider{}, prompts, Options{GroupBy: GroupByProject, ContextTokens: 60})
+
+	contributions := []contrib.Contribution{
+		item("beta", "b1", 10, 100),
+		item("alpha", "a1", 1, 100),
+		item("alpha", "a5", 5, 100),
+		item("beta", "huge", 11, 300),
+		item("alpha", "a3", 3, 100),
+		item("alpha", "a2", 2, 100),
+		item("alpha", "a4", 4, 100),
+	}
+	chunks, dropped, err := s.chunk(contributions, group, prompt.Data{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dropped != 1 {
+		t.Errorf("dropped = %d, want 1 (the huge item)", dropped)
+	}
+
+	want := []struct {
+		group string
+		items []string
+	}{
+		{"github alpha (part 1 of 3)", []string{"a5", "a4"}},
+		{"github alpha (part 2 of 3)", []string{"a3", "a2"}},
+		{"github alpha (part 3 of 3)", []string{"a1"}},
+		{"github beta", []string{"b1"}},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d", len(chunks), len(want))
+	}
+	for i, c := range chunks {
+		if c.group != want[i].group || !slices.Equal(ids(c.items), want[i].items) {
+			t.Errorf("chunk %d = %s %v, want %s %v", i, c.group, ids(c.items), want[i].group, want[i].items)
+		}
+	}
+}
+
+func TestNewestChunks(t *testing.T) {
+	chunks := []chunk{
+		{group: "a", items: []contrib.Contribution{item("a", "a1", 3, 10), item("a", "a2", 9, 10)}},
+		{group: "b", items: []contrib.Contribution{item("b", "b1", 1, 10)}},
+		{group: "c", items: []contrib.Contribution{item("c", "c1", 7, 10)}},
+		{group: "d", items: []contrib.Contribution{item("d", "d1", 2, 10), item("d", "d2", 4, 10), item("d", "d3", 5, 10)}},
+	}
+	tests := []struct {
+		n     int
+		want  []string
+		items int
+	}{
+		{1, []string{"a"}, 2},
+		{2, []string{"a", "c"}, 3},
+		{3, []string{"a", "c", "d"}, 6}, // Original order, not newest first
+		{4, []string{"a", "b", "c", "d"}, 7},
+	}
+	for _, tc := range tests {
+		kept, items := newestChunks(chunks, tc.n)
+		var groups []string
+		for _, c := range kept {
+			groups = append(groups, c.group)
+		}
+		if !slices.Equal(groups, tc.want) || items != tc.items {
+			t.Errorf("newestChunks(%d) = %v with %d items, want %v with %d", tc.n, groups, items, tc.want, tc.items)
+		}
+	}
+}
+
+func TestSummarizeFitsInOnePrompt(t *testing.T) {
+	provider := &fakeProvider{}
+	s := New(provider, testPrompts(t), Options{Template: prompt.SelfEvaluation, ContextTokens: 1000})
+
+	result, err := s.Summarize(context.Background(), []contrib.Contribution{item("alpha", "a1", 1, 20), item("beta", "b1", 2, 20)}, daterange.Range{}, prompt.Profile{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Text != "note 1" || result.Requests != 1 || result.Groups != 0 || result.Included != 2 || result.Dropped != 0 {
+		t.Errorf("result = %+v, want one request including both items", result)
+	}
+	if want := "Based on 2 of 2 items; 1 model request."; result.Coverage() != want {
+		t.Errorf("Coverage() = %q, want %q", result.Coverage(), want)
+	}
+}
+
+func TestSummarizeCountsDroppedItems(t *testing.T) {
+	provider := &fakeProvider{}
+	s := New(provider, testPrompts(t), Options{Template: prompt.SelfEvaluation, GroupBy: GroupByProject, ContextTokens: 60, MaxRequests: 2})
+
+	// Chunked as in TestChunk; the request cap keeps the newest two chunks, beta and alpha's latest part
+	contributions := []contrib.Contribution{
+		item("alpha", "a1", 1, 100),
+		item("alpha", "a2", 2, 100),
+		item("alpha", "a3", 3, 100),
+		item("alpha", "a4", 4, 100),
+		item("alpha", "a5", 5, 100),
+		item("beta", "b1", 10, 100),
+		item("beta", "huge", 11, 300),
+	}
+	result, err := s.Summarize(context.Background(), contributions, daterange.Range{}, prompt.Profile{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Included != 3 || result.Dropped != 4 || result.Groups != 2 || result.Requests != 3 {
+		t.Errorf("result = %+v, want 3 included, 4 dropped, 2 groups and 3 requests", result)
+	}
+	want := "Based on 3 of 7 items (4 dropped to stay within summary limits), summarized in 2 groups; 3 model requests."
+	if result.Coverage() != want {
+		t.Errorf("Coverage() = %q\nwant %q", result.Coverage(), want)
+	}
+
+	if len(provider.prompts) != 3 {
+		t.Fatalf("sent %d prompts, want 3", len(provider.prompts))
+	}
+	for i, prefix := range []string{"github alpha (part 1 of 3)|a5", "github beta|b1", "final|github alpha (part 1 of 3):note 1|github beta:note 2"} {
+		if !strings.HasPrefix(provider.prompts[i], prefix) {
+			t.Errorf("prompt %d = %q, want it to start %q", i, provider.prompts[i], prefix)
+		}
+	}
+}
+
+func TestSummarizeMergesNotesThatDontFit(t *testing.T) {
+	provider := &fakeProvider{}
+	s := New(provider, testPrompts(t), Options{Template: prompt.SelfEvaluation, GroupBy: GroupByProject, ContextTokens: 30})
+
+	// Each 80-character item needs its own request, and the five notes with
+	// their group names are too long for one final prompt
+	var contributions []contrib.Contribution
+	for i, project := range []string{"alpha", "bravo", "charlie", "delta", "echo"} {
+		contributions = append(contributions, item(project+"-service", project[:1]+"1", i+1, 80))
+	}
+	result, err := s.Summarize(context.Background(), contributions, daterange.Range{}, prompt.Profile{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Included != 5 || result.Dropped != 0 || result.Groups != 5 {
+		t.Errorf("result = %+v, want all 5 items in 5 groups", result)
+	}
+	if result.Requests <= 6 {
+		t.Errorf("made %d requests, want more than 5 notes and the final summary, for merging", result.Requests)
+	}
+	if final := provider.prompts[len(provider.prompts)-1]; !strings.HasPrefix(final, "final|") || strings.Count(final, "|") >= 5 {
+		t.Errorf("final prompt = %q, want fewer than 5 merged notes", final)
+	}
+}